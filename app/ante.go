@@ -7,6 +7,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth/ante"
 	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
 	"github.com/cosmos/cosmos-sdk/x/auth/types"
+	feekeeper "github.com/marbar3778/fee/x/fee/keeper"
 )
 
 // HandlerOptions are the options required for constructing a default SDK AnteHandler.
@@ -14,8 +15,16 @@ type HandlerOptions struct {
 	AccountKeeper   ante.AccountKeeper
 	BankKeeper      types.BankKeeper
 	ParamStore      baseapp.ParamStore
+	FeeKeeper       feekeeper.Keeper
 	SignModeHandler authsigning.SignModeHandler
 	SigGasConsumer  func(meter sdk.GasMeter, sig signing.SignatureV2, params types.Params) error
+
+	// ChargeFeesOnSimulate makes simulations exercise the fee deduction
+	// against a discarded cache, see DeductFeeDecorator.
+	ChargeFeesOnSimulate bool
+	// SkipFeesWithoutParams lets txs through the fee decorators unchecked
+	// and uncharged when the fee params are not set, instead of failing them.
+	SkipFeesWithoutParams bool
 }
 
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
@@ -28,20 +37,29 @@ func NewAnteHandler(options HandlerOptions) sdk.AnteHandler {
 		sigGasConsumer = ante.DefaultSigVerificationGasConsumer
 	}
 
+	deductFeeDecorator := NewDeductFeeDecorator(options.AccountKeeper, options.BankKeeper, options.ParamStore, options.FeeKeeper)
+	deductFeeDecorator.ChargeFeesOnSimulate = options.ChargeFeesOnSimulate
+	deductFeeDecorator.SkipFeesWithoutParams = options.SkipFeesWithoutParams
+
+	feeParamDecorator := NewFeeParamDecorator(options.ParamStore, options.FeeKeeper)
+	feeParamDecorator.SkipFeesWithoutParams = options.SkipFeesWithoutParams
+
 	anteDecorators := []sdk.AnteDecorator{
 		ante.NewSetUpContextDecorator(), // outermost AnteDecorator. SetUpContext must be called first
 		ante.NewRejectExtensionOptionsDecorator(),
-		NewFeeParamDecorator(options.ParamStore),
+		feeParamDecorator,
 		ante.NewValidateBasicDecorator(),
 		ante.TxTimeoutHeightDecorator{},
 		ante.NewValidateMemoDecorator(options.AccountKeeper),
 		ante.NewConsumeGasForTxSizeDecorator(options.AccountKeeper),
-		NewDeductFeeDecorator(options.AccountKeeper, options.BankKeeper, options.ParamStore),
 		ante.NewSetPubKeyDecorator(options.AccountKeeper), // SetPubKeyDecorator must be called before all signature verification decorators
 		ante.NewValidateSigCountDecorator(options.AccountKeeper),
 		ante.NewSigGasConsumeDecorator(options.AccountKeeper, sigGasConsumer),
 		ante.NewSigVerificationDecorator(options.AccountKeeper, options.SignModeHandler),
 		ante.NewIncrementSequenceDecorator(options.AccountKeeper),
+		// the single deduction of the whole fee, once the tx is otherwise valid
+		deductFeeDecorator,
+		NewStateRentDecorator(options.FeeKeeper), // must be last so only msg writes pay rent
 	}
 
 	return sdk.ChainAnteDecorators(anteDecorators...)
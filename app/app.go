@@ -144,6 +144,7 @@ var (
 		stakingtypes.NotBondedPoolName: {authtypes.Burner, authtypes.Staking},
 		govtypes.ModuleName:            {authtypes.Burner},
 		ibctransfertypes.ModuleName:    {authtypes.Minter, authtypes.Burner},
+		feetypes.ModuleName:            {authtypes.Burner},
 	}
 )
 
@@ -201,6 +202,10 @@ type App struct {
 	feeKeeper feekeeper.Keeper
 	// this line is used by starport scaffolding # stargate/app/keeperDeclaration
 
+	// deliverHeader is the header of the block being delivered, for the
+	// work DeliverTx does outside the BaseApp, see DeliverTx
+	deliverHeader tmproto.Header
+
 	// the module manager
 	mm *module.Manager
 }
@@ -231,7 +236,7 @@ func New(
 		feetypes.StoreKey,
 		// this line is used by starport scaffolding # stargate/app/storeKey
 	)
-	tkeys := sdk.NewTransientStoreKeys(paramstypes.TStoreKey)
+	tkeys := sdk.NewTransientStoreKeys(paramstypes.TStoreKey, feetypes.TStoreKey)
 	memKeys := sdk.NewMemoryStoreKeys(capabilitytypes.MemStoreKey)
 
 	app := &App{
@@ -247,13 +252,8 @@ func New(
 
 	app.ParamsKeeper = initParamsKeeper(appCodec, cdc, keys[paramstypes.StoreKey], tkeys[paramstypes.TStoreKey])
 
-	paramStore := app.ParamsKeeper.Subspace(baseapp.Paramspace).WithKeyTable(paramskeeper.ConsensusParamsKeyTable())
-	feeParamStore := app.ParamsKeeper.Subspace(FeeParamspace).WithKeyTable(feeParamSet())
-
 	// set the BaseApp's parameter store
-	bApp.SetParamStore(paramStore)
-	// set the fee parameter store
-	bApp.SetParamStore(feeParamStore)
+	bApp.SetParamStore(app.ParamsKeeper.Subspace(baseapp.Paramspace).WithKeyTable(paramskeeper.ConsensusParamsKeyTable()))
 
 	// add capability keeper and ScopeToModule for ibc module
 	app.CapabilityKeeper = capabilitykeeper.NewKeeper(appCodec, keys[capabilitytypes.StoreKey], memKeys[capabilitytypes.MemStoreKey])
@@ -301,14 +301,6 @@ func New(
 		appCodec, keys[ibchost.StoreKey], app.GetSubspace(ibchost.ModuleName), app.StakingKeeper, scopedIBCKeeper,
 	)
 
-	// register the proposal types
-	govRouter := govtypes.NewRouter()
-	govRouter.AddRoute(govtypes.RouterKey, govtypes.ProposalHandler).
-		AddRoute(paramproposal.RouterKey, params.NewParamChangeProposalHandler(app.ParamsKeeper)).
-		AddRoute(distrtypes.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.DistrKeeper)).
-		AddRoute(upgradetypes.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.UpgradeKeeper)).
-		AddRoute(ibchost.RouterKey, ibcclient.NewClientUpdateProposalHandler(app.IBCKeeper.ClientKeeper))
-
 	// Create Transfer Keepers
 	app.TransferKeeper = ibctransferkeeper.NewKeeper(
 		appCodec, keys[ibctransfertypes.StoreKey], app.GetSubspace(ibctransfertypes.ModuleName),
@@ -325,11 +317,27 @@ func New(
 	app.EvidenceKeeper = *evidenceKeeper
 
 	app.feeKeeper = *feekeeper.NewKeeper(
-		appCodec, keys[feetypes.StoreKey], keys[feetypes.MemStoreKey],
+		appCodec, keys[feetypes.StoreKey], keys[feetypes.MemStoreKey], tkeys[feetypes.TStoreKey], app.GetSubspace(feetypes.ModuleName),
+		app.AccountKeeper, app.BankKeeper, app.StakingKeeper, app.DistrKeeper,
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
 	)
+	// Msg fee calculators, and the oracle and fee denom hook once the chain
+	// has them, are set on the keeper here: every copy of it, including the
+	// ones the ante handler and the fee module hold, shares them.
+	if err := app.feeKeeper.SetMsgFeeCalculator(feetypes.MsgTypeURL(&banktypes.MsgMultiSend{}), multiSendOutputFee{perOutput: MultiSendOutputFee}); err != nil {
+		panic(err)
+	}
 
 	// this line is used by starport scaffolding # stargate/app/keeperDefinition
 
+	// register the proposal types
+	govRouter := govtypes.NewRouter()
+	govRouter.AddRoute(govtypes.RouterKey, govtypes.ProposalHandler).
+		AddRoute(paramproposal.RouterKey, fee.NewParamChangeProposalHandler(app.feeKeeper, &app.GovKeeper, params.NewParamChangeProposalHandler(app.ParamsKeeper))).
+		AddRoute(distrtypes.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.DistrKeeper)).
+		AddRoute(upgradetypes.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.UpgradeKeeper)).
+		AddRoute(ibchost.RouterKey, ibcclient.NewClientUpdateProposalHandler(app.IBCKeeper.ClientKeeper))
+
 	app.GovKeeper = govkeeper.NewKeeper(
 		appCodec, keys[govtypes.StoreKey], app.GetSubspace(govtypes.ModuleName), app.AccountKeeper, app.BankKeeper,
 		&stakingKeeper, govRouter,
@@ -380,10 +388,10 @@ func New(
 	// NOTE: staking module is required if HistoricalEntries param > 0
 	app.mm.SetOrderBeginBlockers(
 		upgradetypes.ModuleName, minttypes.ModuleName, distrtypes.ModuleName, slashingtypes.ModuleName,
-		evidencetypes.ModuleName, stakingtypes.ModuleName, ibchost.ModuleName,
+		evidencetypes.ModuleName, stakingtypes.ModuleName, ibchost.ModuleName, feetypes.ModuleName,
 	)
 
-	app.mm.SetOrderEndBlockers(crisistypes.ModuleName, govtypes.ModuleName, stakingtypes.ModuleName)
+	app.mm.SetOrderEndBlockers(crisistypes.ModuleName, govtypes.ModuleName, stakingtypes.ModuleName, feetypes.ModuleName)
 
 	// NOTE: The genutils module must occur after staking so that pools are
 	// properly initialized with tokens from genesis accounts.
@@ -425,13 +433,17 @@ func New(
 			HandlerOptions{
 				AccountKeeper:   app.AccountKeeper,
 				BankKeeper:      app.BankKeeper,
-				ParamStore:      paramStore,
+				ParamStore:      app.GetSubspace(feetypes.ModuleName),
+				FeeKeeper:       app.feeKeeper,
 				SignModeHandler: encodingConfig.TxConfig.SignModeHandler(),
 				SigGasConsumer:  ante.DefaultSigVerificationGasConsumer,
+				// keep simulated gas estimates covering the fee deduction
+				ChargeFeesOnSimulate: true,
 			},
 		),
 	)
 	app.SetEndBlocker(app.EndBlocker)
+	app.registerUpgradeHandlers()
 
 	if loadLatest {
 		if err := app.LoadLatestVersion(); err != nil {
@@ -460,6 +472,7 @@ func (app *App) Name() string { return app.BaseApp.Name() }
 
 // BeginBlocker application updates every begin block
 func (app *App) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	app.deliverHeader = ctx.BlockHeader()
 	return app.mm.BeginBlock(ctx, req)
 }
 
@@ -475,10 +488,13 @@ func (app *App) InitChainer(ctx sdk.Context, req abci.RequestInitChain) abci.Res
 		panic(err)
 	}
 
-	paramStore := app.ParamsKeeper.Subspace(baseapp.Paramspace)
-	paramStore.Set(ctx, ParamStoreKeyfee, FeeParams{Fee: sdk.NewDecCoins(sdk.NewDecCoin("stake", sdk.NewInt(5)))})
+	// the fee ante handlers tell the gentxs delivered by genutil apart by
+	// this mark rather than by the height, which is the initial height
+	app.feeKeeper.SetDeliveringGenesisTxs(ctx, true)
+	res := app.mm.InitGenesis(ctx, app.appCodec, genesisState)
+	app.feeKeeper.SetDeliveringGenesisTxs(ctx, false)
 
-	return app.mm.InitGenesis(ctx, app.appCodec, genesisState)
+	return res
 }
 
 // LoadHeight loads a particular height
@@ -565,7 +581,7 @@ func (app *App) RegisterAPIRoutes(apiSvr *api.Server, apiConfig config.APIConfig
 
 // RegisterTxService implements the Application.RegisterTxService method.
 func (app *App) RegisterTxService(clientCtx client.Context) {
-	authtx.RegisterTxService(app.BaseApp.GRPCQueryRouter(), clientCtx, app.BaseApp.Simulate, app.interfaceRegistry)
+	authtx.RegisterTxService(app.BaseApp.GRPCQueryRouter(), clientCtx, app.Simulate, app.interfaceRegistry)
 }
 
 // RegisterTendermintService implements the Application.RegisterTendermintService method.
@@ -596,6 +612,7 @@ func initParamsKeeper(appCodec codec.BinaryMarshaler, legacyAmino *codec.LegacyA
 	paramsKeeper.Subspace(crisistypes.ModuleName)
 	paramsKeeper.Subspace(ibctransfertypes.ModuleName)
 	paramsKeeper.Subspace(ibchost.ModuleName)
+	paramsKeeper.Subspace(feetypes.ModuleName)
 	// this line is used by starport scaffolding # stargate/app/paramSubspace
 
 	return paramsKeeper
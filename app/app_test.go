@@ -0,0 +1,306 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/simapp/helpers"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	paramproposal "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	feetypes "github.com/marbar3778/fee/x/fee/types"
+)
+
+// findEvent returns the first event of the given type.
+func findEvent(t *testing.T, events []abci.Event, eventType string) abci.Event {
+	for _, event := range events {
+		if event.Type == eventType {
+			return event
+		}
+	}
+
+	require.FailNow(t, "event not found", eventType)
+	return abci.Event{}
+}
+
+// eventAttributes returns the attributes of event by key.
+func eventAttributes(event abci.Event) map[string]string {
+	attrs := make(map[string]string, len(event.Attributes))
+	for _, attr := range event.Attributes {
+		attrs[string(attr.Key)] = string(attr.Value)
+	}
+
+	return attrs
+}
+
+func TestEndBlockerEmitsBlockFeeSummary(t *testing.T) {
+	balance := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10000000))
+	app, accounts := setupWithAccounts(t, func(p *feetypes.FeeParams) { p.BurnRate = sdk.NewDecWithPrec(5, 1) }, balance, balance)
+
+	for i, fee := range []int64{1200000, 1800000} {
+		res := app.deliverTx(t, app.signTx(t, accounts[i], sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, fee)), 200000))
+		require.True(t, res.IsOK(), res.Log)
+	}
+
+	// half of the 3000000stake collected is burned at the end of the block
+	attrs := eventAttributes(findEvent(t, app.nextBlock().Events, feetypes.EventTypeBlockFeeSummary))
+	require.Equal(t, "3000000stake", attrs[feetypes.AttributeKeyCollected])
+	require.Equal(t, "1500000stake", attrs[feetypes.AttributeKeyBurned])
+	require.Equal(t, "400000", attrs[feetypes.AttributeKeyGas])
+}
+
+func TestGenTxsNotChargedAtInitialHeight(t *testing.T) {
+	encCfg := MakeEncodingConfig()
+	priv := secp256k1.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	selfDelegation := sdk.NewInt64Coin(sdk.DefaultBondDenom, 10000000)
+
+	// a create-validator gentx paying no fee
+	msg, err := stakingtypes.NewMsgCreateValidator(
+		sdk.ValAddress(addr), ed25519.GenPrivKey().PubKey(), selfDelegation,
+		stakingtypes.NewDescription("validator", "", "", "", ""),
+		stakingtypes.NewCommissionRates(sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(1, 2)),
+		sdk.OneInt(),
+	)
+	require.NoError(t, err)
+	genTx, err := helpers.GenTx(encCfg.TxConfig, []sdk.Msg{msg}, sdk.NewCoins(), 1000000, "fee-test", []uint64{0}, []uint64{0}, priv)
+	require.NoError(t, err)
+
+	app := New(log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, DefaultNodeHome, 0, encCfg, EmptyAppOptions{})
+	genesisState := NewDefaultGenesisState(app.AppCodec())
+	genesisState[authtypes.ModuleName] = app.AppCodec().MustMarshalJSON(authtypes.NewGenesisState(authtypes.DefaultParams(), []authtypes.GenesisAccount{authtypes.NewBaseAccount(addr, nil, 0, 0)}))
+	balances := []banktypes.Balance{{Address: addr.String(), Coins: sdk.NewCoins(selfDelegation)}}
+	genesisState[banktypes.ModuleName] = app.AppCodec().MustMarshalJSON(banktypes.NewGenesisState(banktypes.DefaultParams(), balances, sdk.NewCoins(selfDelegation), nil))
+	genesisState[genutiltypes.ModuleName] = app.AppCodec().MustMarshalJSON(genutiltypes.NewGenesisStateFromTx(encCfg.TxConfig.TxJSONEncoder(), []sdk.Tx{genTx}))
+
+	// the chain starts past height zero, which genesis txs were told apart by
+	require.NotPanics(t, func() {
+		app.InitChain(abci.RequestInitChain{
+			ChainId:         "fee-test",
+			InitialHeight:   10,
+			ConsensusParams: DefaultConsensusParams,
+			AppStateBytes:   mustMarshalGenesis(genesisState),
+		})
+	})
+
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{Height: 10})
+	_, found := app.StakingKeeper.GetValidator(ctx, sdk.ValAddress(addr))
+	require.True(t, found)
+	require.False(t, app.feeKeeper.IsDeliveringGenesisTxs(ctx))
+}
+
+func TestTxCountOnlyCountsPayingTxs(t *testing.T) {
+	balance := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10000000))
+	app, accounts := setupWithAccounts(t, nil, balance, balance)
+	app.setFeeParams(app.BaseApp.NewContext(false, tmproto.Header{}), func(p *feetypes.FeeParams) {
+		p.FeeAllowancePools = []feetypes.FeeAllowancePool{{
+			Name:      "members",
+			Members:   []string{accounts[0].addr.String(), accounts[1].addr.String()},
+			Allowance: sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100000000)),
+		}}
+	})
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000))
+
+	// two paying txs, and two txs of pool members paying nothing
+	for _, acc := range accounts {
+		res := app.deliverTx(t, app.signTx(t, acc, fee, 200000))
+		require.True(t, res.IsOK(), res.Log)
+		res = app.deliverTx(t, app.signTx(t, acc, nil, 200000))
+		require.True(t, res.IsOK(), res.Log)
+	}
+	height := app.LastBlockHeight() + 1
+	app.nextBlock()
+
+	ctx := app.BaseApp.NewContext(true, tmproto.Header{Height: height + 1})
+	res, err := app.feeKeeper.TxCount(ctx, &feetypes.QueryTxCountRequest{FromHeight: height, ToHeight: height})
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), res.Count)
+}
+
+// feeReader is the view of the fee keeper a sibling module depends on.
+type feeReader interface {
+	GetMinGasPrices(ctx sdk.Context) sdk.DecCoins
+	GetBurnRate(ctx sdk.Context) sdk.Dec
+}
+
+// siblingKeeper is a module keeper reading the fee params in process.
+type siblingKeeper struct {
+	fees feeReader
+}
+
+func (k siblingKeeper) minFee(ctx sdk.Context, gas int64) sdk.DecCoins {
+	return k.fees.GetMinGasPrices(ctx).MulDec(sdk.NewDec(gas))
+}
+
+func TestSiblingModuleReadsFeeParams(t *testing.T) {
+	app, ctx := setupAnte(t)
+	sibling := siblingKeeper{fees: app.feeKeeper}
+
+	require.Equal(t, sdk.NewDecCoins(sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(500))), sibling.minFee(ctx, 100))
+	require.Equal(t, sdk.ZeroDec(), sibling.fees.GetBurnRate(ctx))
+
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) {
+		p.Fee = sdk.NewDecCoins(sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(2)))
+		p.BurnRate = sdk.NewDecWithPrec(3, 1)
+	})
+	require.Equal(t, sdk.NewDecCoins(sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(200))), sibling.minFee(ctx, 100))
+	require.Equal(t, sdk.NewDecWithPrec(3, 1), sibling.fees.GetBurnRate(ctx))
+}
+
+func TestFeeAllowancePoolSharedAndRenewedPerPeriod(t *testing.T) {
+	balance := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100))
+	app, accounts := setupWithAccounts(t, nil, balance, balance)
+	pool := feetypes.FeeAllowancePool{
+		Name:    "members",
+		Members: []string{accounts[0].addr.String(), accounts[1].addr.String()},
+		// three txs of 200000 gas at 5stake
+		Allowance:    sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 3000000)),
+		PeriodBlocks: 10,
+	}
+	app.setFeeParams(app.BaseApp.NewContext(false, tmproto.Header{}), func(p *feetypes.FeeParams) {
+		p.FeeAllowancePools = []feetypes.FeeAllowancePool{pool}
+	})
+	spent := func() sdk.Coins {
+		return app.feeKeeper.GetAllowanceSpent(app.BaseApp.NewContext(false, tmproto.Header{Height: app.LastBlockHeight() + 1}), pool)
+	}
+
+	// CheckTx only makes sure the pool covers the tx
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{Height: app.LastBlockHeight() + 1, ChainID: "fee-test"}).WithIsCheckTx(true)
+	anteHandler := sdk.ChainAnteDecorators(app.feeParamDecorator(), app.deductFeeDecorator())
+	_, err := anteHandler(ctx, newTestTx(t, accounts[0].addr, nil, 200000), false)
+	require.NoError(t, err)
+	require.True(t, spent().Empty())
+
+	// both members draw on the same allowance until it is exhausted
+	for _, acc := range []testAccount{accounts[0], accounts[1], accounts[0]} {
+		res := app.deliverTx(t, app.signTx(t, acc, nil, 200000))
+		require.True(t, res.IsOK(), res.Log)
+	}
+	require.Equal(t, pool.Allowance, spent())
+
+	res := app.deliverTx(t, app.signTx(t, accounts[1], nil, 200000))
+	require.Equal(t, feetypes.ErrAllowanceExhausted.ABCICode(), res.Code, res.Log)
+
+	// the allowance is renewed in the next period, from height 10
+	for app.LastBlockHeight()+1 < 10 {
+		app.nextBlock()
+	}
+	require.True(t, spent().Empty())
+	res = app.deliverTx(t, app.signTx(t, accounts[1], nil, 200000))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000)), spent())
+}
+
+func TestParamChangeProposalRecordsProposalIDAsProvenance(t *testing.T) {
+	app, ctx := setupAnte(t)
+
+	_, err := app.GovKeeper.SubmitProposal(ctx, govtypes.NewTextProposal("unrelated", "not a param change"))
+	require.NoError(t, err)
+
+	params := app.feeKeeper.GetParams(ctx)
+	params.BurnRate = sdk.NewDecWithPrec(25, 2)
+	content := paramproposal.NewParameterChangeProposal("raise burn", "burn a quarter of fees", []paramproposal.ParamChange{
+		paramproposal.NewParamChange(feetypes.ModuleName, string(feetypes.ParamStoreKeyfee), string(app.LegacyAmino().MustMarshalJSON(params))),
+	})
+	proposal, err := app.GovKeeper.SubmitProposal(ctx, content)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), proposal.ProposalId)
+	app.GovKeeper.ActivateVotingPeriod(ctx, proposal)
+	proposal, _ = app.GovKeeper.GetProposal(ctx, proposal.ProposalId)
+
+	// gov executes passing proposals once their voting period ended
+	ctx = ctx.WithBlockTime(proposal.VotingEndTime)
+	require.NoError(t, app.GovKeeper.Router().GetRoute(paramproposal.RouterKey)(ctx, content))
+
+	require.Equal(t, params.BurnRate, app.feeKeeper.GetParams(ctx).BurnRate)
+	provenance, found := app.feeKeeper.CurrentParamsProvenance(ctx)
+	require.True(t, found)
+	require.Equal(t, feetypes.ParamSourceGovernance, provenance.Source)
+	require.Equal(t, "2", provenance.Reference)
+}
+
+func TestParamChangeProposalRejectsInvalidFee(t *testing.T) {
+	for name, fee := range map[string]sdk.DecCoins{
+		"negative":  {{Denom: sdk.DefaultBondDenom, Amount: sdk.NewDec(-1)}},
+		"unsorted":  {{Denom: sdk.DefaultBondDenom, Amount: sdk.OneDec()}, {Denom: "atom", Amount: sdk.OneDec()}},
+		"duplicate": {{Denom: sdk.DefaultBondDenom, Amount: sdk.OneDec()}, {Denom: sdk.DefaultBondDenom, Amount: sdk.OneDec()}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			app, ctx := setupAnte(t)
+			before := app.feeKeeper.GetParams(ctx)
+
+			params := before
+			params.Fee = fee
+			content := paramproposal.NewParameterChangeProposal("set fee", "set an invalid fee", []paramproposal.ParamChange{
+				paramproposal.NewParamChange(feetypes.ModuleName, string(feetypes.ParamStoreKeyfee), string(app.LegacyAmino().MustMarshalJSON(params))),
+			})
+			require.Error(t, app.GovKeeper.Router().GetRoute(paramproposal.RouterKey)(ctx, content))
+			require.Equal(t, before.Fee, app.feeKeeper.GetParams(ctx).Fee)
+		})
+	}
+}
+
+func TestParamChangeProposalValidatesParamsAsAWhole(t *testing.T) {
+	for name, modify := range map[string]func(p *feetypes.FeeParams){
+		// no oracle is wired in the app
+		"value mode without oracle": func(p *feetypes.FeeParams) {
+			p.FeeMode = feetypes.FeeModeValue
+			p.ValueGasPrice = sdk.OneDec()
+		},
+		"whitelist without payable denom": func(p *feetypes.FeeParams) { p.AllowedDenoms = []string{"atom"} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			app, ctx := setupAnte(t)
+			before := app.feeKeeper.GetParams(ctx)
+
+			params := before
+			modify(&params)
+			require.NoError(t, feetypes.ValidateFee(params))
+			content := paramproposal.NewParameterChangeProposal("change fees", "change the fee params", []paramproposal.ParamChange{
+				paramproposal.NewParamChange(feetypes.ModuleName, string(feetypes.ParamStoreKeyfee), string(app.LegacyAmino().MustMarshalJSON(params))),
+			})
+			require.Error(t, app.GovKeeper.Router().GetRoute(paramproposal.RouterKey)(ctx, content))
+			require.Equal(t, before, app.feeKeeper.GetParams(ctx))
+			_, found := app.feeKeeper.GetLastParamChangeHeight(ctx)
+			require.False(t, found)
+		})
+	}
+}
+
+func TestParamChangeProposalsRateLimited(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) { p.MinBlocksBetweenParamChanges = 10 })
+	handler := app.GovKeeper.Router().GetRoute(paramproposal.RouterKey)
+	proposal := func(burnRate sdk.Dec) govtypes.Content {
+		params := app.feeKeeper.GetParams(ctx)
+		params.BurnRate = burnRate
+		return paramproposal.NewParameterChangeProposal("set burn rate", "set the burn rate", []paramproposal.ParamChange{
+			paramproposal.NewParamChange(feetypes.ModuleName, string(feetypes.ParamStoreKeyfee), string(app.LegacyAmino().MustMarshalJSON(params))),
+		})
+	}
+
+	require.NoError(t, handler(ctx, proposal(sdk.NewDecWithPrec(1, 1))))
+	require.Equal(t, sdk.NewDecWithPrec(1, 1), app.feeKeeper.GetBurnRate(ctx))
+
+	// a second change within the window is rejected
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 9)
+	err := handler(ctx, proposal(sdk.NewDecWithPrec(2, 1)))
+	require.ErrorIs(t, err, feetypes.ErrParamChangeTooSoon)
+	require.Equal(t, sdk.NewDecWithPrec(1, 1), app.feeKeeper.GetBurnRate(ctx))
+
+	// and allowed once the window has passed
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	require.NoError(t, handler(ctx, proposal(sdk.NewDecWithPrec(2, 1))))
+	require.Equal(t, sdk.NewDecWithPrec(2, 1), app.feeKeeper.GetBurnRate(ctx))
+}
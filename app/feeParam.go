@@ -2,126 +2,236 @@ package app
 
 import (
 	"fmt"
+	"math/big"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/x/auth/ante"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
-	"github.com/cosmos/cosmos-sdk/x/params/types"
+	feekeeper "github.com/marbar3778/fee/x/fee/keeper"
+	feetypes "github.com/marbar3778/fee/x/fee/types"
 )
 
-var (
-	ParamStoreKeyfee  = []byte("fee")
-	ParamStoreKeyburn = []byte("burn")
-)
-
-const FeeParamspace = "fee"
+// FeeParamDecorator will check if the transaction's fee is at least as large
+// as the fee required by the fee params, and in CheckTx by the local
+// validator's minimum gasFee (defined in validator config) too.
+// If fee is too low, decorator returns error and tx is rejected.
+// If fee is high enough, then call next AnteHandler
+// CONTRACT: Tx must implement FeeTx to use FeeParamDecorator
+type FeeParamDecorator struct {
+	ParamStore baseapp.ParamStore
+	feeKeeper  feekeeper.Keeper
 
-type FeeParams struct {
-	Fee        sdk.DecCoins
-	BurnAmount sdk.Int
+	// SkipFeesWithoutParams lets txs through unchecked in a context without
+	// fee params, see isUninitialized. Otherwise they fail with
+	// ErrParamsNotSet.
+	SkipFeesWithoutParams bool
 }
 
-func NewFeeparam(fee sdk.DecCoins, burnAmount sdk.Int) FeeParams {
-	return FeeParams{
-		Fee:        fee,
-		BurnAmount: burnAmount,
+func NewFeeParamDecorator(params baseapp.ParamStore, fk feekeeper.Keeper) FeeParamDecorator {
+	return FeeParamDecorator{
+		ParamStore: params,
+		feeKeeper:  fk,
 	}
 }
 
-func feeParamSet() types.KeyTable {
-	return types.NewKeyTable(
-		types.NewParamSetPair(
-			ParamStoreKeyfee, FeeParams{}, ValidateFee,
-		),
-	)
-}
-
-func ValidateFee(i interface{}) error {
-	v, ok := i.(FeeParams)
+func (mfd FeeParamDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
+	feeTx, ok := tx.(sdk.FeeTx)
 	if !ok {
-		return fmt.Errorf("invalid parameter type: %T", i)
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "Tx must be a FeeTx")
 	}
 
-	if v.Fee.Empty() {
-		return fmt.Errorf("fee must be positive: %s", v.Fee.String())
+	if len(tx.GetMsgs()) == 0 {
+		return ctx, feetypes.ErrNoMessages
 	}
 
-	if !v.BurnAmount.GTE(sdk.NewInt(0)) {
-		return fmt.Errorf("burn amount must positive: %s ", v.BurnAmount.String())
+	// genesis txs are not subject to the fee rules
+	if isGenesisTx(ctx, mfd.feeKeeper) {
+		return next(ctx, tx, simulate)
 	}
 
-	return nil
-}
+	if isUninitialized(ctx, mfd.ParamStore.Has(ctx, feetypes.ParamStoreKeyfee)) {
+		if mfd.SkipFeesWithoutParams {
+			return next(ctx, tx, simulate)
+		}
+		return ctx, sdkerrors.Wrapf(feetypes.ErrParamsNotSet, "cannot check fees at height %d", ctx.BlockHeight())
+	}
 
-//////
+	feeCoins := feeTx.GetFee()
+	gas := feeTx.GetGas()
 
-// FeeParamDecorator will check if the transaction's fee is at least as large
-// as the local validator's minimum gasFee (defined in validator config).
-// If fee is too low, decorator returns error and tx is rejected from mempool.
-// Note this only applies when ctx.CheckTx = true
-// If fee is high enough or not CheckTx, then call next AnteHandler
-// CONTRACT: Tx must implement FeeTx to use FeeParamDecorator
-type FeeParamDecorator struct {
-	ParamStore baseapp.ParamStore
-}
+	// IsMetBy and the deduction assume sorted, valid coins; reject
+	// malformed fees rather than evaluating them inconsistently.
+	if err := feeCoins.Validate(); err != nil {
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "invalid fee %s: %s", feeCoins, err)
+	}
 
-func NewFeeParamDecorator(params baseapp.ParamStore) FeeParamDecorator {
-	return FeeParamDecorator{
-		ParamStore: params,
+	// fees paid in a display denom are compared in the base denom
+	feeCoins = mfd.feeKeeper.NormalizeFee(ctx, feeCoins)
+
+	var params feetypes.FeeParams
+	mfd.ParamStore.Get(ctx, feetypes.ParamStoreKeyfee, &params)
+
+	// Reject txs declaring less gas than their size warrants. Like the denom
+	// whitelist this is enforced in every mode but simulation, which runs
+	// before the gas limit is known.
+	if params.MinGasPerByte > 0 && !simulate {
+		minGas := uint64(len(ctx.TxBytes())) * params.MinGasPerByte
+		if gas < minGas {
+			return ctx, sdkerrors.Wrapf(feetypes.ErrGasUnderdeclared, "got: %d required: %d for %d bytes", gas, minGas, len(ctx.TxBytes()))
+		}
 	}
-}
 
-func (mfd FeeParamDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
-	feeTx, ok := tx.(sdk.FeeTx)
-	if !ok {
-		return ctx, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "Tx must be a FeeTx")
+	// Txs paying nothing from a member of a fee allowance pool have their
+	// fee waived from the pool's allowance. They are checked as paying the
+	// fee the pool covers for them, and rejected once the pool cannot.
+	_, inPool := params.AllowancePool(feeTx.FeePayer())
+	pooled := feeCoins.IsZero() && inPool
+
+	// fee exempt payers pay no fee, unless they set one that params honor
+	exempt := params.IsFeeExemptPayer(feeTx.FeePayer())
+
+	// Reject txs paying nothing when every tx must pay something, in every
+	// mode but simulation, where the fee is not known yet.
+	if params.RequireNonZeroFee && !simulate && feeCoins.IsZero() && !pooled && !exempt && !params.IsZeroFeeExempt(ctx.ChainID(), msgTypeURLs(tx.GetMsgs())) {
+		if breakdown, err := mfd.feeKeeper.ComputeTotalRequiredFee(ctx, tx); err == nil {
+			recordFeeShortfall(feeCoins, breakdown.Total)
+		}
+		return ctx, feetypes.ErrZeroFee
 	}
 
-	feeCoins := feeTx.GetFee()
-	gas := feeTx.GetGas()
+	// Large fees must come with a memo, in every mode but simulation.
+	if !simulate && params.RequiresMemo(feeCoins) {
+		if memoTx, ok := tx.(sdk.TxWithMemo); !ok || strings.TrimSpace(memoTx.GetMemo()) == "" {
+			return ctx, sdkerrors.Wrapf(feetypes.ErrMemoRequired, "fee %s exceeds %s", feeCoins, params.RequireMemoAboveFee)
+		}
+	}
 
-	// Ensure that the provided fees meet a minimum threshold for the validator,
-	// if this is a CheckTx. This is only for local mempool purposes, and thus
-	// is only ran on check tx.
-	if ctx.IsCheckTx() && !simulate {
-		var minGasPrices sdk.DecCoins
-		mfd.ParamStore.Get(ctx, ParamStoreKeyfee, minGasPrices)
-		if !minGasPrices.IsZero() {
-			requiredFees := make(sdk.Coins, len(minGasPrices))
-
-			// Determine the required fees by multiplying each required minimum gas
-			// price by the gas limit, where fee = ceil(minGasPrice * gasLimit).
-			glDec := sdk.NewDec(int64(gas))
-			for i, gp := range minGasPrices {
-				fee := gp.Amount.Mul(glDec)
-				requiredFees[i] = sdk.NewCoin(gp.Denom, fee.Ceil().RoundInt())
-			}
+	// Reject fees paid in a denom that is not whitelisted, once its grace
+	// period after removal is over, or that the fee denom hook says the payer
+	// cannot spend yet. This runs in every mode, including
+	// ReCheckTx, so txs paying in a denom banned after they entered the
+	// mempool are evicted on recheck.
+	for _, coin := range feeCoins {
+		if !mfd.feeKeeper.IsDenomAccepted(ctx, params, coin.Denom) {
+			return ctx, sdkerrors.Wrapf(feetypes.ErrFeeDenomNotAllowed, "denom: %s", coin.Denom)
+		}
+		if !mfd.feeKeeper.IsFeeDenomSpendable(ctx, feeTx.FeePayer(), coin.Denom) {
+			return ctx, sdkerrors.Wrapf(feetypes.ErrDenomNotSpendable, "denom: %s payer: %s", coin.Denom, feeTx.FeePayer())
+		}
+	}
 
-			if !feeCoins.IsAnyGTE(requiredFees) {
-				return ctx, sdkerrors.Wrapf(sdkerrors.ErrInsufficientFee, "insufficient fees; got: %s required: %s", feeCoins, requiredFees)
+	// Ensure that the provided fees meet the required fee: the gas fee,
+	// ceil(minGasPrice * gasLimit) in any denom with a min gas price, plus any
+	// msg, byte and signature surcharges, always paid in their own denoms. It
+	// follows from consensus params and is enforced when delivering too, so
+	// block proposers cannot include txs underpaying it. Only CheckTx adds
+	// the mempool policy of the node: its local min gas prices, see
+	// EffectiveMinGasPrices, and the load shedding multiplier. In value mode
+	// the fee is checked against the target value when it is deducted
+	// instead.
+	if !simulate && !exempt && params.FeeMode != feetypes.FeeModeValue {
+		breakdown, err := mfd.feeKeeper.ComputeTotalRequiredFee(ctx, tx)
+		if err != nil {
+			return ctx, err
+		}
+
+		if multiplier := mfd.feeKeeper.LoadSheddingMultiplier(ctx); ctx.IsCheckTx() && multiplier.GT(sdk.OneDec()) {
+			breakdown = breakdown.Multiply(multiplier)
+		}
+
+		if pooled {
+			if _, feeCoins, err = mfd.feeKeeper.AllowanceCost(ctx, params, feeTx.FeePayer(), breakdown); err != nil {
+				return ctx, err
 			}
 		}
+
+		requiredFees := breakdown.Total
+		if !breakdown.IsMetBy(feeCoins) {
+			recordFeeShortfall(feeCoins, requiredFees)
+			return ctx, sdkerrors.Wrapf(sdkerrors.ErrInsufficientFee, "insufficient fees; got: %s required: %s", feeCoins, requiredFees)
+		}
+
+		if !requiredFees.IsZero() && params.EmitFullEvents() {
+			emitOverpaidEvent(ctx, params, feeCoins, requiredFees)
+		}
+	}
+
+	// Mempool admission also requires a minimum priority, on top of the
+	// minimum fee. Like the fee check this is local to CheckTx.
+	if ctx.IsCheckTx() && !simulate && !exempt && params.MinMempoolPriority > 0 {
+		if priority := feetypes.TxPriority(feeCoins, gas, params.MinGasPrices()); priority < params.MinMempoolPriority {
+			return ctx, sdkerrors.Wrapf(feetypes.ErrPriorityTooLow, "got: %d required: %d", priority, params.MinMempoolPriority)
+		}
+	}
+
+	if ctx.IsCheckTx() && !ctx.IsReCheckTx() && !simulate && params.EmitMinimalEvents() {
+		mfd.emitPreviewEvent(ctx, params, feeCoins, gas)
 	}
 
 	return next(ctx, tx, simulate)
 }
 
+// msgTypeURLs returns the type URL of every msg.
+func msgTypeURLs(msgs []sdk.Msg) []string {
+	urls := make([]string, len(msgs))
+	for i, msg := range msgs {
+		urls[i] = feetypes.MsgTypeURL(msg)
+	}
+
+	return urls
+}
+
+// emitPreviewEvent attaches a fee_preview event to the CheckTx response with
+// the fee DeliverTx will deduct and the part of it that will be burned.
+func (mfd FeeParamDecorator) emitPreviewEvent(ctx sdk.Context, params feetypes.FeeParams, fee sdk.Coins, gas uint64) {
+	if params.FeeMode == feetypes.FeeModeValue {
+		var err error
+		if fee, err = mfd.feeKeeper.ValueBasedFee(ctx, fee, gas); err != nil {
+			return
+		}
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			params.EventType(feetypes.EventTypeFeePreview),
+			sdk.NewAttribute(feetypes.AttributeKeyFee, fee.String()),
+			sdk.NewAttribute(feetypes.AttributeKeyBurned, mfd.feeKeeper.EstimateBurn(ctx, fee).String()),
+		),
+	)
+}
+
 // DeductFeeDecorator deducts fees from the first signer of the tx
 // If the first signer does not have the funds to pay for the fees, return with InsufficientFunds error
 // Call next AnteHandler if fees successfully deducted
 // CONTRACT: Tx must implement FeeTx interface to use DeductFeeDecorator
+// CONTRACT: DeductFeeDecorator is the only decorator that moves funds. Every
+// fee component (gas, msg, byte, signature and dynamic msg fees) is covered by
+// the single tx fee it deducts, other decorators only check it.
 type DeductFeeDecorator struct {
 	ak         ante.AccountKeeper
 	bankKeeper authtypes.BankKeeper
 	ParamStore baseapp.ParamStore
+	feeKeeper  feekeeper.Keeper
+
+	// ChargeFeesOnSimulate runs the fee deduction during simulation, on a
+	// discarded cache, so the estimated gas includes the fee path.
+	ChargeFeesOnSimulate bool
+	// SkipFeesWithoutParams lets txs through uncharged in a context without
+	// fee params, see isUninitialized. Otherwise they fail with
+	// ErrParamsNotSet.
+	SkipFeesWithoutParams bool
 }
 
-func NewDeductFeeDecorator(ak ante.AccountKeeper, bk authtypes.BankKeeper, params baseapp.ParamStore) DeductFeeDecorator {
+func NewDeductFeeDecorator(ak ante.AccountKeeper, bk authtypes.BankKeeper, params baseapp.ParamStore, fk feekeeper.Keeper) DeductFeeDecorator {
 	return DeductFeeDecorator{
 		ak:         ak,
 		bankKeeper: bk,
+		ParamStore: params,
+		feeKeeper:  fk,
 	}
 }
 
@@ -131,26 +241,192 @@ func (dfd DeductFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bo
 		return ctx, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "Tx must be a FeeTx")
 	}
 
+	if len(tx.GetMsgs()) == 0 {
+		return ctx, feetypes.ErrNoMessages
+	}
+
 	if addr := dfd.ak.GetModuleAddress(authtypes.FeeCollectorName); addr == nil {
 		panic(fmt.Sprintf("%s module account has not been set", authtypes.FeeCollectorName))
 	}
 
+	// genesis txs, such as create-validator gentxs, are not charged
+	if isGenesisTx(ctx, dfd.feeKeeper) {
+		return next(ctx, tx, simulate)
+	}
+
+	if isUninitialized(ctx, dfd.feeKeeper.HasParams(ctx)) {
+		if dfd.SkipFeesWithoutParams {
+			return next(ctx, tx, simulate)
+		}
+		return ctx, sdkerrors.Wrapf(feetypes.ErrParamsNotSet, "cannot deduct fees at height %d", ctx.BlockHeight())
+	}
+
 	feePayer := feeTx.FeePayer()
 	feePayerAcc := dfd.ak.GetAccount(ctx, feePayer)
 
+	// an account can't be funded and pay fees in the same tx, so a missing
+	// payer is rejected rather than created
 	if feePayerAcc == nil {
-		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "fee payer address: %s does not exist", feePayer)
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "fee payer account %s does not exist; it must receive funds before paying fees", feePayer)
+	}
+
+	// Simulations never move funds, whatever fee the tx sets. With
+	// ChargeFeesOnSimulate the deduction runs against a cache, and with its
+	// own event manager, that are both thrown away so only the gas cost is
+	// kept.
+	if simulate {
+		if dfd.ChargeFeesOnSimulate {
+			cacheCtx, _ := ctx.CacheContext()
+			cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+			if err := dfd.deductFee(cacheCtx, feeTx, feePayerAcc); err != nil {
+				return ctx, err
+			}
+		}
+
+		return next(ctx, tx, simulate)
+	}
+
+	if err := dfd.deductFee(ctx, feeTx, feePayerAcc); err != nil {
+		return ctx, err
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// deductFee charges the tx fee to the payer in one send to the fee collector.
+// Denoms with a destination policy are then routed out of the collector and
+// the fee splits paid from what is left.
+func (dfd DeductFeeDecorator) deductFee(ctx sdk.Context, feeTx sdk.FeeTx, feePayerAcc authtypes.AccountI) (err error) {
+	feePayer := feePayerAcc.GetAddress()
+	fee := dfd.feeKeeper.NormalizeFee(ctx, feeTx.GetFee())
+
+	params := dfd.feeKeeper.GetParams(ctx)
+
+	// a fee-less tx from a pool member draws on the pool's allowance once
+	// delivered, FeeParamDecorator checks the pool covers it before
+	if fee.IsZero() {
+		if _, ok := params.AllowancePool(feePayer); ok {
+			if ctx.IsCheckTx() {
+				return nil
+			}
+			return dfd.drawAllowance(ctx, params, feeTx, feePayer)
+		}
+	}
+
+	// fee exempt payers are not charged the fee they set, unless params
+	// honor it
+	if !fee.IsZero() && !params.ExemptHonorsExplicitFee && params.IsFeeExemptPayer(feePayer) {
+		return nil
+	}
+
+	// in value mode only the part of the fee covering the target value is charged
+	if params.FeeMode == feetypes.FeeModeValue {
+		fee, err = dfd.feeKeeper.ValueBasedFee(ctx, fee, feeTx.GetGas())
+		if err != nil {
+			return err
+		}
 	}
 
 	// deduct the fees
-	if !feeTx.GetFee().IsZero() {
-		err = DeductFees(dfd.bankKeeper, ctx, feePayerAcc, feeTx.GetFee())
+	if !fee.IsZero() {
+		if !fee.IsValid() {
+			return sdkerrors.Wrapf(sdkerrors.ErrInsufficientFee, "invalid fee amount: %s", fee)
+		}
+
+		// a payer short of a fee denom pays in the fallback denom, if any
+		spendable := dfd.feeKeeper.SpendableCoins(ctx, feePayer)
+		if !spendable.IsAllGTE(fee) {
+			fee = feetypes.FallbackFee(params, fee, spendable)
+		}
+
+		// the fallback may have switched denoms, so the hook is consulted
+		// again on the fee actually charged
+		for _, coin := range fee {
+			if !dfd.feeKeeper.IsFeeDenomSpendable(ctx, feePayer, coin.Denom) {
+				return sdkerrors.Wrapf(feetypes.ErrDenomNotSpendable, "denom: %s payer: %s", coin.Denom, feePayer)
+			}
+		}
+
+		if !spendable.IsAllGTE(fee) {
+			return sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, "fee payer account %s exists but its spendable balance %s does not cover fee %s", feePayer, spendable, fee)
+		}
+
+		if params.ExceedsBalanceFraction(fee, spendable) {
+			return sdkerrors.Wrapf(feetypes.ErrFeeTooLarge, "fee %s is more than %s of the spendable balance %s of %s", fee, params.MaxFeeFractionOfBalance, spendable, feePayer)
+		}
+
+		if err := dfd.feeKeeper.ChargeSponsorCap(ctx, feePayer, fee); err != nil {
+			return err
+		}
+
+		allocation, err := dfd.transferFee(ctx, feePayerAcc, fee)
 		if err != nil {
-			return ctx, err
+			return err
+		}
+
+		if !ctx.IsCheckTx() {
+			dfd.feeKeeper.RecordFees(ctx, fee, feeTx.GetGas())
+			dfd.feeKeeper.RecordFeeAllocation(ctx, allocation)
+			dfd.feeKeeper.RecordFeeReceipt(ctx, allocation)
+			dfd.feeKeeper.RecordGasCharge(ctx, feePayer, allocation.Collected, feeTx.GetGas())
 		}
+
+		emitDeductedEvents(ctx, params, feePayer, fee, feeTx.GetGas())
 	}
 
-	return next(ctx, tx, simulate)
+	return nil
+}
+
+// isGenesisTx reports whether the tx is delivered at genesis through
+// DeliverGenTxs, within InitChain.
+func isGenesisTx(ctx sdk.Context, fk feekeeper.Keeper) bool {
+	return !ctx.IsCheckTx() && fk.IsDeliveringGenesisTxs(ctx)
+}
+
+// isUninitialized reports whether the fee rules cannot be applied in ctx: the
+// fee params are not set, as in tests or migrations running before genesis,
+// or the height is negative. Reading the params there would panic.
+func isUninitialized(ctx sdk.Context, hasParams bool) bool {
+	return ctx.BlockHeight() < 0 || !hasParams
+}
+
+// drawAllowance waives the fee of a fee-less tx by a pool member, charging
+// the fee it is required to pay to the pool's allowance.
+func (dfd DeductFeeDecorator) drawAllowance(ctx sdk.Context, params feetypes.FeeParams, feeTx sdk.FeeTx, feePayer sdk.AccAddress) error {
+	breakdown, err := dfd.feeKeeper.ComputeTotalRequiredFee(ctx, feeTx)
+	if err != nil {
+		return err
+	}
+
+	return dfd.feeKeeper.DrawAllowance(ctx, params, feePayer, breakdown)
+}
+
+// transferFee moves fee from the payer to its destinations. The whole fee
+// leaves the payer in a single send, routing and splits are then paid out of
+// the fee collector. The transfers run on a cache context written only once
+// all of them succeeded, so a failure part way leaves no partial deduction
+// behind. It returns where the fee actually went.
+func (dfd DeductFeeDecorator) transferFee(ctx sdk.Context, feePayerAcc authtypes.AccountI, fee sdk.Coins) (feetypes.FeeAllocation, error) {
+	cacheCtx, write := ctx.CacheContext()
+
+	if err := DeductFees(dfd.bankKeeper, cacheCtx, feePayerAcc, fee); err != nil {
+		return feetypes.FeeAllocation{}, err
+	}
+
+	routed, remainder, err := dfd.feeKeeper.RouteFees(cacheCtx, fee)
+	if err != nil {
+		return feetypes.FeeAllocation{}, sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, err.Error())
+	}
+
+	split, err := dfd.feeKeeper.PayFeeSplits(cacheCtx, remainder)
+	if err != nil {
+		return feetypes.FeeAllocation{}, sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, err.Error())
+	}
+
+	write()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+
+	return routed.Add(split), nil
 }
 
 // DeductFees deducts fees from the given account.
@@ -166,3 +442,88 @@ func DeductFees(bankKeeper authtypes.BankKeeper, ctx sdk.Context, acc authtypes.
 
 	return nil
 }
+
+// emitDeductedEvents emits the fee_deducted event and, with full verbosity,
+// the effective gas price paid per denom.
+func emitDeductedEvents(ctx sdk.Context, params feetypes.FeeParams, payer sdk.AccAddress, fee sdk.Coins, gas uint64) {
+	if !params.EmitMinimalEvents() {
+		return
+	}
+
+	// payer and denoms are indexed so fees can be searched by either
+	deducted := sdk.NewEvent(params.EventType(feetypes.EventTypeFeeDeducted))
+	deducted.Attributes = append(deducted.Attributes,
+		feetypes.NewIndexedAttribute(feetypes.AttributeKeyPayer, payer.String()),
+		sdk.NewAttribute(feetypes.AttributeKeyFee, fee.String()).ToKVPair(),
+	)
+	for _, coin := range fee {
+		deducted.Attributes = append(deducted.Attributes, feetypes.NewIndexedAttribute(feetypes.AttributeKeyDenom, coin.Denom))
+	}
+	ctx.EventManager().EmitEvent(deducted)
+
+	if !params.EmitFullEvents() || gas == 0 {
+		return
+	}
+
+	gasPrices := sdk.NewDecCoins()
+	for _, coin := range fee {
+		gasPrices = gasPrices.Add(sdk.NewDecCoinFromDec(coin.Denom, coin.Amount.ToDec().QuoInt64(int64(gas))))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			params.EventType(feetypes.EventTypeGasPrice),
+			sdk.NewAttribute(feetypes.AttributeKeyGas, fmt.Sprintf("%d", gas)),
+			sdk.NewAttribute(feetypes.AttributeKeyGasPrice, gasPrices.String()),
+		),
+	)
+}
+
+// emitOverpaidEvent warns when the fee exceeds the required fee in a denom.
+func emitOverpaidEvent(ctx sdk.Context, params feetypes.FeeParams, fee, requiredFees sdk.Coins) {
+	overpaid := sdk.NewCoins()
+	for _, coin := range fee {
+		required := requiredFees.AmountOf(coin.Denom)
+		if required.IsPositive() && coin.Amount.GT(required) {
+			overpaid = overpaid.Add(sdk.NewCoin(coin.Denom, coin.Amount.Sub(required)))
+		}
+	}
+
+	if overpaid.Empty() {
+		return
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			params.EventType(feetypes.EventTypeOverpaid),
+			sdk.NewAttribute(feetypes.AttributeKeyFee, fee.String()),
+			sdk.NewAttribute(feetypes.AttributeKeyRequiredFee, requiredFees.String()),
+			sdk.NewAttribute(feetypes.AttributeKeyOverpaid, overpaid.String()),
+		),
+	)
+}
+
+// recordFeeShortfall records, per denom the rejected fee was paid in, how far
+// it fell short of the required fee, so operators can see how far off
+// rejected txs are: the number of such txs and the latest shortfall. An empty
+// fee falls short in every denom of the required fee.
+func recordFeeShortfall(fee, requiredFees sdk.Coins) {
+	paid := fee
+	if fee.IsZero() {
+		paid = sdk.Coins{}
+		for _, coin := range requiredFees {
+			paid = append(paid, sdk.NewCoin(coin.Denom, sdk.ZeroInt()))
+		}
+	}
+
+	for _, coin := range paid {
+		shortfall := requiredFees.AmountOf(coin.Denom).Sub(coin.Amount)
+		if !shortfall.IsPositive() {
+			continue
+		}
+
+		amount, _ := new(big.Float).SetInt(shortfall.BigInt()).Float32()
+		telemetry.IncrCounter(1, feetypes.ModuleName, "rejected_fee", coin.Denom)
+		telemetry.SetGauge(amount, feetypes.ModuleName, "rejected_fee_shortfall", coin.Denom)
+	}
+}
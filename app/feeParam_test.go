@@ -0,0 +1,925 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/simapp/helpers"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	feetypes "github.com/marbar3778/fee/x/fee/types"
+)
+
+// setupAnte returns an app past genesis together with a deliver context at
+// height 2.
+func setupAnte(t *testing.T) (*App, sdk.Context) {
+	app := SetupWithGenesisAccounts(nil, nil)
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{Height: 2, ChainID: "fee-test"})
+
+	return app, ctx
+}
+
+// testAccount is a genesis account able to sign txs.
+type testAccount struct {
+	priv cryptotypes.PrivKey
+	addr sdk.AccAddress
+}
+
+// setupWithAccounts returns an app past genesis with an account per balance,
+// its fee params changed by modify.
+func setupWithAccounts(t *testing.T, modify func(*feetypes.FeeParams), balances ...sdk.Coins) (*App, []testAccount) {
+	accounts := make([]testAccount, len(balances))
+	genAccs := make([]authtypes.GenesisAccount, len(balances))
+	genBalances := make([]banktypes.Balance, len(balances))
+	for i, balance := range balances {
+		priv := secp256k1.GenPrivKey()
+		accounts[i] = testAccount{priv: priv, addr: sdk.AccAddress(priv.PubKey().Address())}
+		genAccs[i] = authtypes.NewBaseAccount(accounts[i].addr, nil, 0, 0)
+		genBalances[i] = banktypes.Balance{Address: accounts[i].addr.String(), Coins: balance}
+	}
+
+	app := SetupWithGenesisAccounts(func(genesisState GenesisState) {
+		if modify == nil {
+			return
+		}
+
+		cdc := MakeEncodingConfig().Marshaler
+		var feeGenesis feetypes.GenesisState
+		cdc.MustUnmarshalJSON(genesisState[feetypes.ModuleName], &feeGenesis)
+		modify(&feeGenesis.Params.FeeParams)
+		genesisState[feetypes.ModuleName] = cdc.MustMarshalJSON(&feeGenesis)
+	}, genAccs, genBalances...)
+
+	return app, accounts
+}
+
+// signTx signs a msg send from acc to a new address paying fee for gas, at
+// the account's current sequence.
+func (app *App) signTx(t *testing.T, acc testAccount, fee sdk.Coins, gas uint64) sdk.Tx {
+	msg := banktypes.NewMsgSend(acc.addr, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)))
+	return app.signTxWithMsgs(t, acc, []sdk.Msg{msg}, fee, gas)
+}
+
+func (app *App) signTxWithMsgs(t *testing.T, acc testAccount, msgs []sdk.Msg, fee sdk.Coins, gas uint64) sdk.Tx {
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{})
+	account := app.AccountKeeper.GetAccount(ctx, acc.addr)
+	require.NotNil(t, account)
+
+	tx, err := helpers.GenTx(
+		MakeEncodingConfig().TxConfig, msgs, fee, gas, "",
+		[]uint64{account.GetAccountNumber()}, []uint64{account.GetSequence()}, acc.priv,
+	)
+	require.NoError(t, err)
+
+	return tx
+}
+
+func encodeTx(t *testing.T, tx sdk.Tx) []byte {
+	bz, err := MakeEncodingConfig().TxConfig.TxEncoder()(tx)
+	require.NoError(t, err)
+
+	return bz
+}
+
+// deliverTx delivers tx in the current block.
+func (app *App) deliverTx(t *testing.T, tx sdk.Tx) abci.ResponseDeliverTx {
+	return app.DeliverTx(abci.RequestDeliverTx{Tx: encodeTx(t, tx)})
+}
+
+// nextBlock ends and commits the current block and begins the next one.
+func (app *App) nextBlock() abci.ResponseEndBlock {
+	height := app.LastBlockHeight() + 1
+	res := app.EndBlock(abci.RequestEndBlock{Height: height})
+	app.Commit()
+	app.BeginBlock(abci.RequestBeginBlock{Header: tmproto.Header{Height: height + 1}})
+
+	return res
+}
+
+func (app *App) balance(addr sdk.AccAddress) sdk.Coins {
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{})
+	return app.BankKeeper.GetAllBalances(ctx, addr)
+}
+
+// newTestAddr returns a new random account address.
+func newTestAddr() sdk.AccAddress {
+	return sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+}
+
+// newTestTx builds an unsigned tx paying fee for gas, with payer as the
+// signer of its msg send.
+func newTestTx(t *testing.T, payer sdk.AccAddress, fee sdk.Coins, gas uint64) sdk.Tx {
+	msg := banktypes.NewMsgSend(payer, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)))
+	return newTestTxWithMsgs(t, []sdk.Msg{msg}, fee, gas)
+}
+
+func newTestTxWithMsgs(t *testing.T, msgs []sdk.Msg, fee sdk.Coins, gas uint64) sdk.Tx {
+	txBuilder := MakeEncodingConfig().TxConfig.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(msgs...))
+	txBuilder.SetFeeAmount(fee)
+	txBuilder.SetGasLimit(gas)
+
+	return txBuilder.GetTx()
+}
+
+// setFeeParams updates the fee params with modify.
+func (app *App) setFeeParams(ctx sdk.Context, modify func(*feetypes.FeeParams)) {
+	params := app.feeKeeper.GetParams(ctx)
+	modify(&params)
+	app.feeKeeper.SetParams(ctx, params)
+}
+
+// nextAnte ends an ante chain under test.
+func nextAnte(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+	return ctx, nil
+}
+
+func (app *App) feeParamDecorator() FeeParamDecorator {
+	return NewFeeParamDecorator(app.GetSubspace(feetypes.ModuleName), app.feeKeeper)
+}
+
+func (app *App) deductFeeDecorator() DeductFeeDecorator {
+	return NewDeductFeeDecorator(app.AccountKeeper, app.BankKeeper, app.GetSubspace(feetypes.ModuleName), app.feeKeeper)
+}
+
+func TestFeeDecoratorsRejectTxWithoutMsgs(t *testing.T) {
+	app, ctx := setupAnte(t)
+	tx := newTestTxWithMsgs(t, nil, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100)), 100000)
+
+	for _, checkTx := range []bool{true, false} {
+		ctx := ctx.WithIsCheckTx(checkTx)
+
+		_, err := app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+		require.ErrorIs(t, err, feetypes.ErrNoMessages)
+
+		_, err = app.deductFeeDecorator().AnteHandle(ctx, tx, false, nextAnte)
+		require.ErrorIs(t, err, feetypes.ErrNoMessages)
+	}
+}
+
+func TestFeeParamDecoratorRejectsBannedDenomOnRecheck(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) {
+		p.Fee = sdk.NewDecCoins(sdk.NewDecCoin("atom", sdk.OneInt()), sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(5)))
+	})
+	tx := newTestTx(t, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin("atom", 100000)), 100000)
+
+	ctx = ctx.WithIsCheckTx(true)
+	_, err := app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.NoError(t, err)
+
+	// governance bans atom while the tx sits in the mempool
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) { p.AllowedDenoms = []string{sdk.DefaultBondDenom} })
+
+	_, err = app.feeParamDecorator().AnteHandle(ctx.WithIsReCheckTx(true), tx, false, nextAnte)
+	require.ErrorIs(t, err, feetypes.ErrFeeDenomNotAllowed)
+}
+
+// fixedOracle converts fee denoms at fixed rates.
+type fixedOracle map[string]sdk.Dec
+
+func (o fixedOracle) GetExchangeRate(_ sdk.Context, denom string) (sdk.Dec, error) {
+	rate, ok := o[denom]
+	if !ok {
+		return sdk.Dec{}, feetypes.ErrNoOracle
+	}
+
+	return rate, nil
+}
+
+func TestDeductFeeDecoratorValueModeCombinesDenoms(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.feeKeeper.SetOracle(fixedOracle{"atom": sdk.NewDec(2), sdk.DefaultBondDenom: sdk.OneDec()})
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) {
+		p.FeeMode = feetypes.FeeModeValue
+		p.ValueGasPrice = sdk.OneDec()
+	})
+
+	payer := newTestAddr()
+	require.NoError(t, FundAccount(app, ctx, payer, sdk.NewCoins(sdk.NewInt64Coin("atom", 1000), sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000))))
+
+	// 100 gas is worth 100: the 30atom are worth 60, the rest is drawn in stake
+	tx := newTestTx(t, payer, sdk.NewCoins(sdk.NewInt64Coin("atom", 30), sdk.NewInt64Coin(sdk.DefaultBondDenom, 100)), 100)
+	_, err := app.deductFeeDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.NoError(t, err)
+
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("atom", 970), sdk.NewInt64Coin(sdk.DefaultBondDenom, 960)), app.BankKeeper.GetAllBalances(ctx, payer))
+}
+
+// feeEventTypes returns the types of the fee events emitted in ctx, in order.
+func feeEventTypes(ctx sdk.Context) []string {
+	feeEvents := map[string]bool{
+		feetypes.EventTypeFeePreview:  true,
+		feetypes.EventTypeFeeDeducted: true,
+		feetypes.EventTypeGasPrice:    true,
+		feetypes.EventTypeOverpaid:    true,
+	}
+
+	var eventTypes []string
+	for _, event := range ctx.EventManager().Events() {
+		if feeEvents[event.Type] {
+			eventTypes = append(eventTypes, event.Type)
+		}
+	}
+
+	return eventTypes
+}
+
+func TestFeeEventVerbosity(t *testing.T) {
+	for _, tc := range []struct {
+		verbosity string
+		expected  []string
+	}{
+		{feetypes.EventVerbosityNone, nil},
+		{feetypes.EventVerbosityMinimal, []string{feetypes.EventTypeFeePreview, feetypes.EventTypeFeeDeducted}},
+		{feetypes.EventVerbosityFull, []string{feetypes.EventTypeOverpaid, feetypes.EventTypeFeePreview, feetypes.EventTypeFeeDeducted, feetypes.EventTypeGasPrice}},
+	} {
+		t.Run(tc.verbosity, func(t *testing.T) {
+			app, ctx := setupAnte(t)
+			app.setFeeParams(ctx, func(p *feetypes.FeeParams) { p.EventVerbosity = tc.verbosity })
+
+			payer := newTestAddr()
+			require.NoError(t, FundAccount(app, ctx, payer, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000))))
+			tx := newTestTx(t, payer, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 600000)), 100000)
+
+			ctx = ctx.WithIsCheckTx(true).WithEventManager(sdk.NewEventManager())
+			_, err := app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+			require.NoError(t, err)
+			_, err = app.deductFeeDecorator().AnteHandle(ctx, tx, false, nextAnte)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expected, feeEventTypes(ctx))
+		})
+	}
+}
+
+func TestFeeDeductedEventIndexesPayerAndDenoms(t *testing.T) {
+	app, ctx := setupAnte(t)
+	payer := newTestAddr()
+	require.NoError(t, FundAccount(app, ctx, payer, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000))))
+	tx := newTestTx(t, payer, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 600000)), 100000)
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	_, err := app.deductFeeDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.NoError(t, err)
+
+	indexed := make(map[string]bool)
+	for _, attr := range findEvent(t, ctx.EventManager().ABCIEvents(), feetypes.EventTypeFeeDeducted).Attributes {
+		indexed[string(attr.Key)] = attr.Index
+	}
+	require.Equal(t, map[string]bool{
+		feetypes.AttributeKeyPayer: true,
+		feetypes.AttributeKeyFee:   false,
+		feetypes.AttributeKeyDenom: true,
+	}, indexed)
+}
+
+func TestDeductFeeDecoratorChargeFeesOnSimulate(t *testing.T) {
+	app, ctx := setupAnte(t)
+	payer := newTestAddr()
+	balance := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000))
+	require.NoError(t, FundAccount(app, ctx, payer, balance))
+	tx := newTestTx(t, payer, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 500000)), 100000)
+
+	gasUsed := func(chargeFees bool) uint64 {
+		dfd := app.deductFeeDecorator()
+		dfd.ChargeFeesOnSimulate = chargeFees
+
+		ctx := ctx.WithIsCheckTx(true).WithGasMeter(sdk.NewInfiniteGasMeter())
+		_, err := dfd.AnteHandle(ctx, tx, true, nextAnte)
+		require.NoError(t, err)
+
+		return ctx.GasMeter().GasConsumed()
+	}
+
+	require.Greater(t, gasUsed(true), gasUsed(false))
+	require.Equal(t, balance, app.BankKeeper.GetAllBalances(ctx, payer))
+}
+
+func TestFeeParamDecoratorRejectsUnsortedFee(t *testing.T) {
+	app, ctx := setupAnte(t)
+	unsorted := sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 600000), sdk.NewInt64Coin("atom", 1)}
+	tx := newTestTx(t, newTestAddr(), unsorted, 100000)
+
+	for _, checkTx := range []bool{true, false} {
+		_, err := app.feeParamDecorator().AnteHandle(ctx.WithIsCheckTx(checkTx), tx, false, nextAnte)
+		require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+	}
+}
+
+func TestDeductFeeDecoratorPayerErrors(t *testing.T) {
+	app, ctx := setupAnte(t)
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 500000))
+
+	missing := newTestAddr()
+	_, err := app.deductFeeDecorator().AnteHandle(ctx, newTestTx(t, missing, fee, 100000), false, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrUnknownAddress)
+
+	unfunded := newTestAddr()
+	app.AccountKeeper.SetAccount(ctx, app.AccountKeeper.NewAccountWithAddress(ctx, unfunded))
+	_, err = app.deductFeeDecorator().AnteHandle(ctx, newTestTx(t, unfunded, fee, 100000), false, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFunds)
+}
+
+func TestFeeParamDecoratorAcceptsDisplayDenom(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) {
+		p.Fee = sdk.NewDecCoins(sdk.NewDecCoinFromDec("uatom", sdk.NewDecWithPrec(1, 2)))
+	})
+	app.BankKeeper.SetDenomMetaData(ctx, banktypes.Metadata{
+		Base:       "uatom",
+		DenomUnits: []*banktypes.DenomUnit{{Denom: "uatom", Exponent: 0}, {Denom: "atom", Exponent: 6}},
+	})
+
+	// 1atom is 1000000uatom, well above the 1000uatom required
+	tx := newTestTx(t, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin("atom", 1)), 100000)
+	_, err := app.feeParamDecorator().AnteHandle(ctx.WithIsCheckTx(true), tx, false, nextAnte)
+	require.NoError(t, err)
+}
+
+func TestDeductFeeDecoratorNeverChargesSimulatedTx(t *testing.T) {
+	app, ctx := setupAnte(t)
+	payer := newTestAddr()
+	balance := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000))
+	require.NoError(t, FundAccount(app, ctx, payer, balance))
+	tx := newTestTx(t, payer, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 500000)), 100000)
+
+	for _, checkTx := range []bool{true, false} {
+		for _, chargeFees := range []bool{true, false} {
+			dfd := app.deductFeeDecorator()
+			dfd.ChargeFeesOnSimulate = chargeFees
+
+			ctx := ctx.WithIsCheckTx(checkTx).WithEventManager(sdk.NewEventManager())
+			_, err := dfd.AnteHandle(ctx, tx, true, nextAnte)
+			require.NoError(t, err)
+
+			require.Equal(t, balance, app.BankKeeper.GetAllBalances(ctx, payer))
+			require.Empty(t, feeEventTypes(ctx))
+		}
+	}
+}
+
+func TestFeeParamDecoratorRejectsUnderdeclaredGas(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) { p.MinGasPerByte = 10 })
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100000000))
+
+	// a large tx sending to many addresses
+	msgs := make([]sdk.Msg, 50)
+	for i := range msgs {
+		msgs[i] = banktypes.NewMsgSend(newTestAddr(), newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)))
+	}
+	txWithGas := func(gas uint64) (sdk.Tx, sdk.Context) {
+		tx := newTestTxWithMsgs(t, msgs, fee, gas)
+		return tx, ctx.WithTxBytes(encodeTx(t, tx))
+	}
+
+	// gas limits encoding to a varint of the same length keep the tx size
+	_, txCtx := txWithGas(100000)
+	minGas := uint64(len(txCtx.TxBytes())) * 10
+
+	tx, txCtx := txWithGas(minGas - 1)
+	_, err := app.feeParamDecorator().AnteHandle(txCtx, tx, false, nextAnte)
+	require.ErrorIs(t, err, feetypes.ErrGasUnderdeclared)
+
+	tx, txCtx = txWithGas(minGas)
+	_, err = app.feeParamDecorator().AnteHandle(txCtx, tx, false, nextAnte)
+	require.NoError(t, err)
+}
+
+func TestDeductFeeDecoratorChargesFallbackDenom(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) {
+		p.FallbackDenom = "atom"
+		p.FallbackPenalty = sdk.NewDecWithPrec(5, 1)
+	})
+
+	payer := newTestAddr()
+	require.NoError(t, FundAccount(app, ctx, payer, sdk.NewCoins(sdk.NewInt64Coin("atom", 1000))))
+
+	// the payer holds no stake, so the 101stake are paid as 152atom
+	tx := newTestTx(t, payer, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 101)), 10)
+	_, err := app.deductFeeDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.NoError(t, err)
+
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("atom", 848)), app.BankKeeper.GetAllBalances(ctx, payer))
+}
+
+func TestDeductFeeDecoratorSponsorCapPerBlock(t *testing.T) {
+	app, accounts := setupWithAccounts(t, nil, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000)))
+	sponsor := accounts[0]
+	app.setFeeParams(app.BaseApp.NewContext(false, tmproto.Header{}), func(p *feetypes.FeeParams) {
+		p.Fee = sdk.NewDecCoins(sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, sdk.NewDecWithPrec(1, 3)))
+		p.SponsorSpendCaps = []feetypes.SponsorSpendCap{{Address: sponsor.addr.String(), PerBlockCap: sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000))}}
+	})
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 400))
+
+	// two 400stake fees fit the 1000stake cap, a third does not
+	for i := 0; i < 2; i++ {
+		res := app.deliverTx(t, app.signTx(t, sponsor, fee, 200000))
+		require.True(t, res.IsOK(), res.Log)
+	}
+	res := app.deliverTx(t, app.signTx(t, sponsor, fee, 200000))
+	require.Equal(t, feetypes.ErrSponsorCapExceeded.ABCICode(), res.Code, res.Log)
+
+	// the cap is per block
+	app.nextBlock()
+	res = app.deliverTx(t, app.signTx(t, sponsor, fee, 200000))
+	require.True(t, res.IsOK(), res.Log)
+}
+
+func TestDeductFeeDecoratorSponsorCapNotSpentInCheckTx(t *testing.T) {
+	app, accounts := setupWithAccounts(t, nil, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000)))
+	sponsor := accounts[0]
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 400))
+	app.setFeeParams(app.BaseApp.NewContext(false, tmproto.Header{}), func(p *feetypes.FeeParams) {
+		p.Fee = sdk.NewDecCoins(sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, sdk.NewDecWithPrec(1, 3)))
+		p.SponsorSpendCaps = []feetypes.SponsorSpendCap{{Address: sponsor.addr.String(), PerBlockCap: fee}}
+	})
+	// CheckTx runs on the committed state
+	app.nextBlock()
+	spent := func() sdk.Coins {
+		return app.feeKeeper.GetSponsorSpent(app.BaseApp.NewContext(false, tmproto.Header{}), sponsor.addr)
+	}
+
+	// the tx passing CheckTx does not use up the cap it is delivered under
+	tx := encodeTx(t, app.signTx(t, sponsor, fee, 200000))
+	checkRes := app.CheckTx(abci.RequestCheckTx{Tx: tx})
+	require.True(t, checkRes.IsOK(), checkRes.Log)
+	res := app.DeliverTx(abci.RequestDeliverTx{Tx: tx})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, fee, spent())
+
+	// the cap is used up for the block once delivered, not for the mempool
+	tx = encodeTx(t, app.signTx(t, sponsor, fee, 200000))
+	checkRes = app.CheckTx(abci.RequestCheckTx{Tx: tx})
+	require.True(t, checkRes.IsOK(), checkRes.Log)
+	res = app.DeliverTx(abci.RequestDeliverTx{Tx: tx})
+	require.Equal(t, feetypes.ErrSponsorCapExceeded.ABCICode(), res.Code, res.Log)
+}
+
+func TestFeeParamDecoratorEnforcesSurchargesWhenDelivering(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) {
+		p.MsgFee = sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100))
+		p.SignatureFee = sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 300))
+	})
+	payer := newTestAddr()
+
+	// the gas fee alone does not cover the msg surcharge
+	tx := newTestTx(t, payer, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 500000)), 100000)
+	_, err := app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	// the unsigned tx pays no signature surcharge
+	tx = newTestTx(t, payer, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 500100)), 100000)
+	_, err = app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.NoError(t, err)
+}
+
+func TestFeeParamDecoratorEmitsPreviewOnCheckTx(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) { p.BurnRate = sdk.NewDecWithPrec(5, 1) })
+	tx := newTestTx(t, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 600000)), 100000)
+
+	ctx = ctx.WithIsCheckTx(true).WithEventManager(sdk.NewEventManager())
+	_, err := app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.NoError(t, err)
+
+	// half of the fee reaching the collector is burned
+	require.Contains(t, ctx.EventManager().Events(), sdk.NewEvent(
+		feetypes.EventTypeFeePreview,
+		sdk.NewAttribute(feetypes.AttributeKeyFee, "600000stake"),
+		sdk.NewAttribute(feetypes.AttributeKeyBurned, "300000stake"),
+	))
+
+	// delivering previews nothing
+	ctx = ctx.WithIsCheckTx(false).WithEventManager(sdk.NewEventManager())
+	_, err = app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.NoError(t, err)
+	require.NotContains(t, feeEventTypes(ctx), feetypes.EventTypeFeePreview)
+}
+
+func TestFeeParamDecoratorRejectsLowPriority(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) { p.MinMempoolPriority = 10 })
+
+	// 6stake per gas covers the 5stake min gas price but not the priority
+	tx := newTestTx(t, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 600000)), 100000)
+	_, err := app.feeParamDecorator().AnteHandle(ctx.WithIsCheckTx(true), tx, false, nextAnte)
+	require.ErrorIs(t, err, feetypes.ErrPriorityTooLow)
+
+	// the priority is mempool policy only
+	_, err = app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.NoError(t, err)
+
+	tx = newTestTx(t, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000)), 100000)
+	_, err = app.feeParamDecorator().AnteHandle(ctx.WithIsCheckTx(true), tx, false, nextAnte)
+	require.NoError(t, err)
+}
+
+func TestFeeParamDecoratorRequireNonZeroFee(t *testing.T) {
+	app, ctx := setupAnte(t)
+	tx := newTestTx(t, newTestAddr(), sdk.NewCoins(), 0)
+
+	// nothing is required for zero gas
+	_, err := app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.NoError(t, err)
+
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) { p.RequireNonZeroFee = true })
+	_, err = app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.ErrorIs(t, err, feetypes.ErrZeroFee)
+}
+
+func TestFeeParamDecoratorHonorsChainZeroFeeExemptions(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) {
+		p.RequireNonZeroFee = true
+		p.ChainZeroFeeExemptions = []feetypes.ChainZeroFeeExemption{
+			{ChainID: "fee-test", MsgTypes: []string{feetypes.MsgTypeURL(&banktypes.MsgSend{})}},
+		}
+	})
+	tx := newTestTx(t, newTestAddr(), sdk.NewCoins(), 0)
+
+	_, err := app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.NoError(t, err)
+
+	// the exemption is specific to its chain-id
+	_, err = app.feeParamDecorator().AnteHandle(ctx.WithChainID("other-chain"), tx, false, nextAnte)
+	require.ErrorIs(t, err, feetypes.ErrZeroFee)
+
+	// and only lifts the non-zero fee requirement, not the required fee
+	_, err = app.feeParamDecorator().AnteHandle(ctx, newTestTx(t, newTestAddr(), sdk.NewCoins(), 100000), false, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+}
+
+// countingBankKeeper counts the fee payments sent to module accounts.
+type countingBankKeeper struct {
+	authtypes.BankKeeper
+	sends []sdk.Coins
+}
+
+func (bk *countingBankKeeper) SendCoinsFromAccountToModule(ctx sdk.Context, sender sdk.AccAddress, module string, amt sdk.Coins) error {
+	bk.sends = append(bk.sends, amt)
+	return bk.BankKeeper.SendCoinsFromAccountToModule(ctx, sender, module, amt)
+}
+
+func TestFeeDecoratorsDeductCombinedFeeOnce(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) {
+		p.MsgFee = sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100))
+	})
+	payer := newTestAddr()
+	require.NoError(t, FundAccount(app, ctx, payer, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000))))
+
+	// the 500000stake gas fee and the 100stake msg fee
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 500100))
+	bank := &countingBankKeeper{BankKeeper: app.BankKeeper}
+	anteHandler := sdk.ChainAnteDecorators(
+		app.feeParamDecorator(),
+		NewDeductFeeDecorator(app.AccountKeeper, bank, app.GetSubspace(feetypes.ModuleName), app.feeKeeper),
+	)
+	_, err := anteHandler(ctx, newTestTx(t, payer, fee, 100000), false)
+	require.NoError(t, err)
+
+	require.Equal(t, []sdk.Coins{fee}, bank.sends)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 499900)), app.BankKeeper.GetAllBalances(ctx, payer))
+}
+
+func TestFeeParamDecoratorAcceptsRemovedDenomDuringGrace(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) {
+		p.Fee = sdk.NewDecCoins(sdk.NewDecCoin("atom", sdk.OneInt()), sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(5)))
+		p.AllowedDenoms = []string{"atom", sdk.DefaultBondDenom}
+	})
+
+	// atom is dropped from the whitelist at height 2 with a 3 block grace
+	params := app.feeKeeper.GetParams(ctx)
+	params.AllowedDenoms = []string{sdk.DefaultBondDenom}
+	params.GraceBlocksAfterDenomRemoval = 3
+	require.NoError(t, app.feeKeeper.UpdateParams(ctx, app.feeKeeper.GetAuthority(), params))
+
+	tx := newTestTx(t, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin("atom", 100000)), 100000)
+	for _, height := range []int64{2, 4} {
+		_, err := app.feeParamDecorator().AnteHandle(ctx.WithBlockHeight(height), tx, false, nextAnte)
+		require.NoError(t, err, "height %d", height)
+	}
+
+	_, err := app.feeParamDecorator().AnteHandle(ctx.WithBlockHeight(5), tx, false, nextAnte)
+	require.ErrorIs(t, err, feetypes.ErrFeeDenomNotAllowed)
+}
+
+func TestFeeParamDecoratorEnforcesPerBlockMinFeePerDenom(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) {
+		p.Fee = sdk.NewDecCoins(sdk.NewDecCoin("atom", sdk.OneInt()), sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(5)))
+		p.PerBlockMinFee = sdk.NewCoins(sdk.NewInt64Coin("atom", 500000), sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000))
+	})
+	ctx = ctx.WithIsCheckTx(false)
+
+	// the gas fee of 1000 gas is far below the floor
+	tx := newTestTx(t, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10000)), 1000)
+	_, err := app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	// the floor is met in any single denom
+	for _, fee := range []sdk.Coins{
+		sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000)),
+		sdk.NewCoins(sdk.NewInt64Coin("atom", 500000)),
+	} {
+		tx := newTestTx(t, newTestAddr(), fee, 1000)
+		_, err := app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+		require.NoError(t, err, fee.String())
+	}
+}
+
+func TestFeeParamDecoratorShedsLoadOnCheckTx(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) {
+		p.TargetBlockGas = 1000000
+		p.LoadSheddingThreshold = sdk.NewDecWithPrec(8, 1)
+		p.LoadSheddingMultiplier = sdk.NewDec(2)
+	})
+	tx := newTestTx(t, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 500000)), 100000)
+	checkCtx := ctx.WithIsCheckTx(true)
+
+	app.feeKeeper.SetLastBlockGas(ctx, 800000)
+	_, err := app.feeParamDecorator().AnteHandle(checkCtx, tx, false, nextAnte)
+	require.NoError(t, err)
+
+	// over the threshold mempool admission requires twice the fee
+	app.feeKeeper.SetLastBlockGas(ctx, 900000)
+	_, err = app.feeParamDecorator().AnteHandle(checkCtx, tx, false, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	doubled := newTestTx(t, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000)), 100000)
+	_, err = app.feeParamDecorator().AnteHandle(checkCtx, doubled, false, nextAnte)
+	require.NoError(t, err)
+
+	// the multiplier is a mempool policy, delivering is unaffected
+	_, err = app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.NoError(t, err)
+}
+
+func TestDeductFeeDecoratorMidSplitFailureLeavesNoDeduction(t *testing.T) {
+	app, ctx := setupAnte(t)
+	first := newTestAddr()
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) {
+		p.FeeSplits = []feetypes.FeeSplit{
+			{Address: first.String(), Weight: sdk.NewDecWithPrec(25, 2)},
+			// module accounts are blocked from receiving sends, failing the
+			// second split after the first one was paid
+			{Address: authtypes.NewModuleAddress(feetypes.ModuleName).String(), Weight: sdk.NewDecWithPrec(25, 2)},
+		}
+	})
+	payer := newTestAddr()
+	balance := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000))
+	require.NoError(t, FundAccount(app, ctx, payer, balance))
+	collector := app.AccountKeeper.GetModuleAddress(authtypes.FeeCollectorName)
+	collected := app.BankKeeper.GetAllBalances(ctx, collector)
+
+	tx := newTestTx(t, payer, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 600000)), 100000)
+	_, err := app.deductFeeDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFunds)
+	require.Contains(t, err.Error(), "not allowed to receive funds")
+
+	require.Equal(t, balance, app.BankKeeper.GetAllBalances(ctx, payer))
+	require.True(t, app.BankKeeper.GetAllBalances(ctx, first).Empty())
+	require.Equal(t, collected, app.BankKeeper.GetAllBalances(ctx, collector))
+}
+
+func TestFeeParamDecoratorRequiresMemoAboveFee(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) {
+		p.RequireMemoAboveFee = sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000))
+	})
+
+	txBuilder := MakeEncodingConfig().TxConfig.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(banktypes.NewMsgSend(newTestAddr(), newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)))))
+	txBuilder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 2000000)))
+	txBuilder.SetGasLimit(100000)
+
+	_, err := app.feeParamDecorator().AnteHandle(ctx, txBuilder.GetTx(), false, nextAnte)
+	require.ErrorIs(t, err, feetypes.ErrMemoRequired)
+
+	txBuilder.SetMemo("invoice 42")
+	_, err = app.feeParamDecorator().AnteHandle(ctx, txBuilder.GetTx(), false, nextAnte)
+	require.NoError(t, err)
+}
+
+func TestFeeParamDecoratorRecordsShortfallPerPaidDenom(t *testing.T) {
+	m, err := telemetry.New(telemetry.Config{Enabled: true, ServiceName: "fee-test"})
+	require.NoError(t, err)
+
+	app, ctx := setupAnte(t)
+
+	txBuilder := MakeEncodingConfig().TxConfig.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(banktypes.NewMsgSend(newTestAddr(), newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)))))
+	txBuilder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)))
+	txBuilder.SetGasLimit(100000)
+
+	_, err = app.feeParamDecorator().AnteHandle(ctx, txBuilder.GetTx(), false, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	gathered, err := m.Gather("")
+	require.NoError(t, err)
+	require.Contains(t, string(gathered.Metrics), "fee.rejected_fee.stake")
+	require.Contains(t, string(gathered.Metrics), "fee.rejected_fee_shortfall.stake")
+}
+
+func TestFeeParamDecoratorRecordsShortfallOfEmptyFee(t *testing.T) {
+	for _, requireNonZeroFee := range []bool{false, true} {
+		m, err := telemetry.New(telemetry.Config{Enabled: true, ServiceName: "fee-test"})
+		require.NoError(t, err)
+
+		app, ctx := setupAnte(t)
+		app.setFeeParams(ctx, func(p *feetypes.FeeParams) { p.RequireNonZeroFee = requireNonZeroFee })
+
+		_, err = app.feeParamDecorator().AnteHandle(ctx, newTestTx(t, newTestAddr(), nil, 100000), false, nextAnte)
+		require.Error(t, err)
+
+		// a tx paying nothing falls short in the denoms of the required fee
+		gathered, err := m.Gather("")
+		require.NoError(t, err)
+		require.Contains(t, string(gathered.Metrics), "fee.rejected_fee.stake")
+		require.Contains(t, string(gathered.Metrics), "fee.rejected_fee_shortfall.stake")
+	}
+}
+
+func TestDeductFeeDecoratorRejectsFeeAboveBalanceFraction(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) { p.MaxFeeFractionOfBalance = sdk.NewDecWithPrec(5, 1) })
+	payer := newTestAddr()
+	balance := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000))
+	require.NoError(t, FundAccount(app, ctx, payer, balance))
+
+	tx := newTestTx(t, payer, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 500001)), 100000)
+	_, err := app.deductFeeDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.ErrorIs(t, err, feetypes.ErrFeeTooLarge)
+	require.Equal(t, balance, app.BankKeeper.GetAllBalances(ctx, payer))
+
+	// exactly half of the balance is still accepted
+	tx = newTestTx(t, payer, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 500000)), 100000)
+	_, err = app.deductFeeDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 500000)), app.BankKeeper.GetAllBalances(ctx, payer))
+}
+
+func TestFeeExemptPayerChargedExplicitFeeOnlyWhenHonored(t *testing.T) {
+	for _, honor := range []bool{false, true} {
+		app, ctx := setupAnte(t)
+		payer := newTestAddr()
+		app.setFeeParams(ctx, func(p *feetypes.FeeParams) {
+			p.FeeExemptPayers = []string{payer.String()}
+			p.ExemptHonorsExplicitFee = honor
+		})
+		balance := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000))
+		require.NoError(t, FundAccount(app, ctx, payer, balance))
+
+		// the fee set is well below the required 500000stake
+		fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100))
+		tx := newTestTx(t, payer, fee, 100000)
+		_, err := app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+		require.NoError(t, err)
+		_, err = app.deductFeeDecorator().AnteHandle(ctx, tx, false, nextAnte)
+		require.NoError(t, err)
+
+		if honor {
+			require.Equal(t, balance.Sub(fee), app.BankKeeper.GetAllBalances(ctx, payer))
+		} else {
+			require.Equal(t, balance, app.BankKeeper.GetAllBalances(ctx, payer))
+		}
+	}
+
+	// payers not listed still pay the required fee
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) { p.FeeExemptPayers = []string{newTestAddr().String()} })
+	tx := newTestTx(t, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100)), 100000)
+	_, err := app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+}
+
+// lockedDenomHook marks denom non-spendable for locked.
+type lockedDenomHook struct {
+	locked sdk.AccAddress
+	denom  string
+}
+
+func (h lockedDenomHook) IsFeeDenomSpendable(_ sdk.Context, payer sdk.AccAddress, denom string) bool {
+	return !payer.Equals(h.locked) || denom != h.denom
+}
+
+func TestFeeDenomHookSetAfterWiringRejectsNonSpendableDenom(t *testing.T) {
+	balance := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10000000))
+	app, accs := setupWithAccounts(t, nil, balance, balance)
+
+	// the ante handler was built with copies of the keeper before the hook
+	// is set, and still consults it
+	app.feeKeeper.SetFeeDenomHook(lockedDenomHook{locked: accs[0].addr, denom: sdk.DefaultBondDenom})
+
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000))
+	res := app.deliverTx(t, app.signTx(t, accs[0], fee, 200000))
+	require.Equal(t, feetypes.ErrDenomNotSpendable.ABCICode(), res.Code, res.Log)
+	require.Equal(t, balance, app.balance(accs[0].addr))
+
+	res = app.deliverTx(t, app.signTx(t, accs[1], fee, 200000))
+	require.True(t, res.IsOK(), res.Log)
+}
+
+func TestFeeParamDecoratorEnforcesFlatMinFeeOnLowGasTx(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) {
+		p.Fee = sdk.NewDecCoins(sdk.NewDecCoin("atom", sdk.OneInt()), sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(5)))
+		p.MinFeePerDenom = sdk.NewCoins(sdk.NewInt64Coin("atom", 50000))
+	})
+
+	// 1000 gas needs 1000atom by its gas price, the flat minimum in atom is
+	// higher
+	tx := newTestTx(t, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin("atom", 49999)), 1000)
+	_, err := app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	for _, fee := range []sdk.Coins{
+		sdk.NewCoins(sdk.NewInt64Coin("atom", 50000)),
+		// denoms without a flat minimum only pay for their gas
+		sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 5000)),
+	} {
+		tx := newTestTx(t, newTestAddr(), fee, 1000)
+		_, err := app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+		require.NoError(t, err, fee.String())
+	}
+}
+
+func TestFeeEventsPrefixedWithEventTypePrefix(t *testing.T) {
+	balance := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10000000))
+	app, accs := setupWithAccounts(t, func(p *feetypes.FeeParams) { p.EventTypePrefix = "mychain" }, balance)
+
+	res := app.deliverTx(t, app.signTx(t, accs[0], sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000)), 200000))
+	require.True(t, res.IsOK(), res.Log)
+
+	findEvent(t, res.Events, "mychain."+feetypes.EventTypeFeeDeducted)
+	for _, event := range res.Events {
+		require.False(t, strings.HasPrefix(event.Type, "fee_"), event.Type)
+	}
+}
+
+func TestComputePriorityMatchesFeeParamDecorator(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) {
+		p.Fee = sdk.NewDecCoins(sdk.NewDecCoin("atom", sdk.OneInt()), sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(5)))
+	})
+	ctx = ctx.WithIsCheckTx(true)
+
+	for _, fee := range []sdk.Coins{
+		sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 6000000)),
+		sdk.NewCoins(sdk.NewInt64Coin("atom", 300000), sdk.NewInt64Coin(sdk.DefaultBondDenom, 6000000)),
+	} {
+		res, err := app.feeKeeper.ComputePriority(ctx, &feetypes.QueryComputePriorityRequest{Fee: fee, Gas: 100000})
+		require.NoError(t, err)
+		require.Positive(t, res.Priority, fee.String())
+
+		// the decorator admits the tx at exactly the computed priority
+		tx := newTestTx(t, newTestAddr(), fee, 100000)
+		app.setFeeParams(ctx, func(p *feetypes.FeeParams) { p.MinMempoolPriority = res.Priority })
+		_, err = app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+		require.NoError(t, err, fee.String())
+
+		app.setFeeParams(ctx, func(p *feetypes.FeeParams) { p.MinMempoolPriority = res.Priority + 1 })
+		_, err = app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+		require.ErrorIs(t, err, feetypes.ErrPriorityTooLow, fee.String())
+	}
+}
+
+func TestFeeDecoratorsWithoutParams(t *testing.T) {
+	// without InitChain the fee params were never set
+	app := Setup(true)
+	ctx := app.BaseApp.NewContext(true, tmproto.Header{Height: 2, ChainID: "fee-test"})
+	require.False(t, app.feeKeeper.HasParams(ctx))
+	tx := newTestTx(t, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)), 100000)
+
+	_, err := app.feeParamDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.ErrorIs(t, err, feetypes.ErrParamsNotSet)
+	_, err = app.deductFeeDecorator().AnteHandle(ctx, tx, false, nextAnte)
+	require.ErrorIs(t, err, feetypes.ErrParamsNotSet)
+
+	// skipping lets the tx through unchecked and uncharged, even though its
+	// payer has no account
+	feeParamDecorator := app.feeParamDecorator()
+	feeParamDecorator.SkipFeesWithoutParams = true
+	_, err = feeParamDecorator.AnteHandle(ctx, tx, false, nextAnte)
+	require.NoError(t, err)
+
+	deductFeeDecorator := app.deductFeeDecorator()
+	deductFeeDecorator.SkipFeesWithoutParams = true
+	_, err = deductFeeDecorator.AnteHandle(ctx, tx, false, nextAnte)
+	require.NoError(t, err)
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// MultiSendOutputFee is the fee a MsgMultiSend pays per output past the first.
+var MultiSendOutputFee = sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100))
+
+// multiSendOutputFee charges a MsgMultiSend perOutput for every output past
+// the first, so fanning out in one msg costs no less than separate sends.
+type multiSendOutputFee struct {
+	perOutput sdk.Coins
+}
+
+// ComputeMsgFee implements feetypes.MsgFeeCalculator.
+func (c multiSendOutputFee) ComputeMsgFee(_ sdk.Context, msg sdk.Msg) sdk.Coins {
+	multiSend, ok := msg.(*banktypes.MsgMultiSend)
+	if !ok || len(multiSend.Outputs) <= 1 {
+		return sdk.NewCoins()
+	}
+
+	fee := sdk.NewCoins()
+	for _, coin := range c.perOutput {
+		fee = fee.Add(sdk.NewCoin(coin.Denom, coin.Amount.MulRaw(int64(len(multiSend.Outputs)-1))))
+	}
+
+	return fee
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	feetypes "github.com/marbar3778/fee/x/fee/types"
+)
+
+func newMultiSend(from sdk.AccAddress, outputs int) *banktypes.MsgMultiSend {
+	coin := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1))
+	msg := &banktypes.MsgMultiSend{Inputs: []banktypes.Input{banktypes.NewInput(from, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, int64(outputs))))}}
+	for i := 0; i < outputs; i++ {
+		msg.Outputs = append(msg.Outputs, banktypes.NewOutput(newTestAddr(), coin))
+	}
+
+	return msg
+}
+
+func TestMultiSendOutputFeeScalesWithOutputs(t *testing.T) {
+	app, ctx := setupAnte(t)
+	from := newTestAddr()
+
+	for _, tc := range []struct {
+		outputs int
+		dynamic int64
+	}{
+		{1, 0},
+		{2, 100},
+		{5, 400},
+	} {
+		tx := newTestTxWithMsgs(t, []sdk.Msg{newMultiSend(from, tc.outputs)}, nil, 200000)
+		breakdown, err := app.feeKeeper.ComputeTotalRequiredFee(ctx, tx)
+		require.NoError(t, err)
+		require.Equal(t, tc.dynamic, breakdown.Dynamic.AmountOf(sdk.DefaultBondDenom).Int64(), "outputs: %d", tc.outputs)
+	}
+
+	// a type URL takes a single calculator
+	err := app.feeKeeper.SetMsgFeeCalculator(feetypes.MsgTypeURL(&banktypes.MsgMultiSend{}), multiSendOutputFee{})
+	require.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	feetypes "github.com/marbar3778/fee/x/fee/types"
+)
+
+// DeliverTx delivers the tx and, when the fee module recorded a fee receipt
+// for it, appends the receipt to the result data as an extra msg data entry
+// of type feetypes.FeeReceiptMsgType. Ante handlers cannot set result data
+// themselves, so the receipt is attached here. The gas used is only known
+// here as well, so this is also where the unused gas refund and the gas over
+// declaration penalty are settled, their events appended to the result.
+//
+// The ante handler writes persist when the msgs fail, so the receipt of a
+// failed tx is recorded too; it is dropped rather than attached.
+func (app *App) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	res := app.BaseApp.DeliverTx(req)
+
+	ctx := app.BaseApp.NewContext(false, app.deliverHeader)
+	app.feeKeeper.SettleGasCharge(ctx, uint64(res.GasUsed))
+	res.Events = append(res.Events, ctx.EventManager().ABCIEvents()...)
+
+	receipt, ok := app.feeKeeper.TakeFeeReceipt(ctx)
+	if !ok || !res.IsOK() {
+		return res
+	}
+
+	bz, err := receipt.Marshal()
+	if err != nil {
+		app.Logger().Error("failed to encode fee receipt", "err", err)
+		return res
+	}
+
+	var data sdk.TxMsgData
+	if err := data.Unmarshal(res.Data); err != nil {
+		app.Logger().Error("failed to decode tx result data", "err", err)
+		return res
+	}
+	data.Data = append(data.Data, &sdk.MsgData{MsgType: feetypes.FeeReceiptMsgType, Data: bz})
+
+	withReceipt, err := data.Marshal()
+	if err != nil {
+		app.Logger().Error("failed to encode tx result data", "err", err)
+		return res
+	}
+	res.Data = withReceipt
+
+	return res
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	feetypes "github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestDeliverTxAttachesFeeReceipt(t *testing.T) {
+	balance := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000))
+	app, accounts := setupWithAccounts(t, func(p *feetypes.FeeParams) {
+		p.Fee = sdk.NewDecCoins(sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, sdk.NewDecWithPrec(1, 3)))
+		p.EmitFeeReceipt = true
+		p.DestinationPolicies = []feetypes.DestinationPolicy{{Denom: sdk.DefaultBondDenom, Destination: feetypes.DestinationBurn}}
+		// burning would take the supply below its minimum, so the fee stays
+		// with the collector
+		p.KeepMinSupply = []feetypes.MinSupply{{Denom: sdk.DefaultBondDenom, Amount: sdk.NewInt(1000000)}}
+	}, balance)
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 200))
+
+	res := app.deliverTx(t, app.signTx(t, accounts[0], fee, 200000))
+	require.True(t, res.IsOK(), res.Log)
+
+	var data sdk.TxMsgData
+	require.NoError(t, data.Unmarshal(res.Data))
+	require.Len(t, data.Data, 2)
+	require.Equal(t, feetypes.FeeReceiptMsgType, data.Data[1].MsgType)
+
+	var receipt feetypes.FeeReceipt
+	require.NoError(t, receipt.Unmarshal(data.Data[1].Data))
+	require.Equal(t, fee, receipt.Collected)
+	require.True(t, receipt.Burned.Empty())
+
+	// the receipt of a tx whose msgs fail is dropped
+	send := banktypes.NewMsgSend(accounts[0].addr, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 2000000)))
+	res = app.deliverTx(t, app.signTxWithMsgs(t, accounts[0], []sdk.Msg{send}, fee, 200000))
+	require.False(t, res.IsOK())
+	require.Empty(t, res.Data)
+
+	_, found := app.feeKeeper.TakeFeeReceipt(app.BaseApp.NewContext(false, tmproto.Header{}))
+	require.False(t, found)
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	feetypes "github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestFailedTxRefundedUnusedGasBeforeBurn(t *testing.T) {
+	balance := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10000000))
+	app, accounts := setupWithAccounts(t, func(p *feetypes.FeeParams) {
+		p.BurnRate = sdk.NewDecWithPrec(5, 1)
+		p.RefundUnusedGas = true
+	}, balance)
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 2000000))
+
+	// the send fails, the fee stays charged
+	send := banktypes.NewMsgSend(accounts[0].addr, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 20000000)))
+	res := app.deliverTx(t, app.signTxWithMsgs(t, accounts[0], []sdk.Msg{send}, fee, 400000))
+	require.False(t, res.IsOK())
+	require.Equal(t, balance.Sub(fee), app.balance(accounts[0].addr))
+
+	// the gas fee actually used is kept and half of it is burned, the rest of
+	// the prepaid fee is refunded
+	charged := sdk.NewInt(res.GasUsed).MulRaw(2000000).ToDec().QuoInt64(400000).Ceil().TruncateInt()
+	refund := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, fee.AmountOf(sdk.DefaultBondDenom).Sub(charged)))
+	burned := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, charged.QuoRaw(2)))
+
+	events := app.nextBlock().Events
+	attrs := eventAttributes(findEvent(t, events, feetypes.EventTypeGasRefund))
+	require.Equal(t, accounts[0].addr.String(), attrs[feetypes.AttributeKeyPayer])
+	require.Equal(t, refund.String(), attrs[feetypes.AttributeKeyRefund])
+	require.Equal(t, burned.String(), eventAttributes(findEvent(t, events, feetypes.EventTypeBurnFees))[feetypes.AttributeKeyAmount])
+	require.Equal(t, balance.Sub(fee).Add(refund...), app.balance(accounts[0].addr))
+}
+
+func TestGasOverDeclarationPenalty(t *testing.T) {
+	for _, refundUnusedGas := range []bool{false, true} {
+		balance := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100000000))
+		app, accounts := setupWithAccounts(t, func(p *feetypes.FeeParams) {
+			p.RefundUnusedGas = refundUnusedGas
+			p.GasOverDeclarationThreshold = sdk.NewDec(5)
+			p.GasOverDeclarationPenalty = sdk.NewDecWithPrec(1, 1)
+		}, balance)
+		payer := accounts[0].addr
+
+		// a tx declaring about twice the gas it uses is not penalized
+		res := app.deliverTx(t, app.signTx(t, accounts[0], sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000)), 200000))
+		require.True(t, res.IsOK(), res.Log)
+		for _, event := range res.Events {
+			require.NotEqual(t, feetypes.EventTypeGasPenalty, event.Type)
+		}
+		app.nextBlock()
+
+		// a tx declaring 10x the gas it uses is charged a tenth of its fee
+		gas := uint64(res.GasUsed) * 10
+		fee := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewIntFromUint64(gas*5)))
+		penalty := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, fee.AmountOf(sdk.DefaultBondDenom).QuoRaw(10)))
+		// the balance before the tx, net of the coin it sends
+		before := app.balance(payer).Sub(sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)))
+
+		res = app.deliverTx(t, app.signTx(t, accounts[0], fee, gas))
+		require.True(t, res.IsOK(), res.Log)
+		attrs := eventAttributes(findEvent(t, res.Events, feetypes.EventTypeGasPenalty))
+		require.Equal(t, payer.String(), attrs[feetypes.AttributeKeyPayer])
+		require.Equal(t, penalty.String(), attrs[feetypes.AttributeKeyAmount])
+
+		// the charge is settled against the header of the delivered block
+		require.Equal(t, app.LastBlockHeight()+1, app.deliverHeader.Height)
+
+		if !refundUnusedGas {
+			// without a refund to withhold it from, the kept fee covers the
+			// penalty and nothing more is taken from the payer
+			require.Empty(t, attrs[feetypes.AttributeKeyWithheld])
+			require.Equal(t, before.Sub(fee), app.balance(payer))
+			continue
+		}
+
+		// the penalty is withheld from the refund of the unused gas
+		require.Equal(t, penalty.String(), attrs[feetypes.AttributeKeyWithheld])
+		refund := feetypes.UnusedGasRefund(fee, gas, uint64(res.GasUsed)).Sub(penalty)
+		require.Equal(t, refund.String(), eventAttributes(findEvent(t, app.nextBlock().Events, feetypes.EventTypeGasRefund))[feetypes.AttributeKeyRefund])
+		require.Equal(t, before.Sub(fee).Add(refund...), app.balance(payer))
+	}
+}
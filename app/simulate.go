@@ -0,0 +1,30 @@
+package app
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	feetypes "github.com/marbar3778/fee/x/fee/types"
+)
+
+// Simulate runs the tx in simulation mode and attaches a fee_preview event to
+// the result carrying the fee the fee module requires for the gas used, so
+// clients don't need a separate query to price the tx.
+func (app *App) Simulate(txBytes []byte) (sdk.GasInfo, *sdk.Result, error) {
+	gasInfo, res, err := app.BaseApp.Simulate(txBytes)
+	if err != nil || res == nil {
+		return gasInfo, res, err
+	}
+
+	ctx := app.BaseApp.NewContext(true, tmproto.Header{})
+	requiredFees := app.feeKeeper.RequiredFees(ctx, gasInfo.GasUsed)
+
+	res.Events = append(res.Events, sdk.Events{
+		sdk.NewEvent(
+			app.feeKeeper.GetParams(ctx).EventType(feetypes.EventTypeFeePreview),
+			sdk.NewAttribute(feetypes.AttributeKeyRequiredFee, requiredFees.String()),
+		),
+	}.ToABCIEvents()...)
+
+	return gasInfo, res, nil
+}
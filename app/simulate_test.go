@@ -0,0 +1,38 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	feetypes "github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestSimulateAttachesRequiredFee(t *testing.T) {
+	app, accounts := setupWithAccounts(t, nil, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10000000)))
+	tx := app.signTx(t, accounts[0], sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000)), 200000)
+
+	gasInfo, res, err := app.Simulate(encodeTx(t, tx))
+	require.NoError(t, err)
+	require.NotZero(t, gasInfo.GasUsed)
+
+	// the default min gas price is 5stake
+	ctx := app.BaseApp.NewContext(true, tmproto.Header{})
+	expected := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, int64(gasInfo.GasUsed)*5))
+	require.Equal(t, expected, app.feeKeeper.RequiredFees(ctx, gasInfo.GasUsed))
+
+	var preview []string
+	for _, event := range res.Events {
+		if event.Type != feetypes.EventTypeFeePreview {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if string(attr.Key) == feetypes.AttributeKeyRequiredFee {
+				preview = append(preview, string(attr.Value))
+			}
+		}
+	}
+	require.Equal(t, []string{expected.String()}, preview)
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	feekeeper "github.com/marbar3778/fee/x/fee/keeper"
+)
+
+const gasStateRentDesc = "StateRent"
+
+// StateRentDecorator charges state rent on the bytes a tx writes to state.
+// The SDK has no post handler to charge after the msgs ran, so the rent is
+// charged as gas as the writes happen, through a gas meter that consumes
+// StateRentRate extra gas per byte written. The rent is paid out of the fee
+// prepaid for the gas limit, and a tx whose limit does not cover it runs out
+// of gas.
+//
+// It must be the last decorator, so only the writes of the msgs pay rent.
+type StateRentDecorator struct {
+	feeKeeper feekeeper.Keeper
+}
+
+func NewStateRentDecorator(fk feekeeper.Keeper) StateRentDecorator {
+	return StateRentDecorator{
+		feeKeeper: fk,
+	}
+}
+
+func (srd StateRentDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
+	rate := srd.feeKeeper.GetParams(ctx).StateRentRate
+	if rate == 0 {
+		return next(ctx, tx, simulate)
+	}
+
+	return next(ctx.WithGasMeter(newRentGasMeter(ctx.GasMeter(), rate)), tx, simulate)
+}
+
+// rentGasMeter wraps a gas meter and charges rentPerByte extra gas for every
+// byte the KV stores report as written.
+type rentGasMeter struct {
+	sdk.GasMeter
+
+	rentPerByte uint64
+	writeCost   uint64
+}
+
+func newRentGasMeter(gasMeter sdk.GasMeter, rentPerByte uint64) *rentGasMeter {
+	return &rentGasMeter{
+		GasMeter:    gasMeter,
+		rentPerByte: rentPerByte,
+		writeCost:   storetypes.KVGasConfig().WriteCostPerByte,
+	}
+}
+
+func (m *rentGasMeter) ConsumeGas(amount sdk.Gas, descriptor string) {
+	m.GasMeter.ConsumeGas(amount, descriptor)
+
+	if descriptor != storetypes.GasWritePerByteDesc || m.writeCost == 0 {
+		return
+	}
+
+	written := amount / m.writeCost
+	m.GasMeter.ConsumeGas(written*m.rentPerByte, gasStateRentDesc)
+}
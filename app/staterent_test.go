@@ -0,0 +1,34 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	feetypes "github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestStateRentDecoratorChargesWrites(t *testing.T) {
+	app, ctx := setupAnte(t)
+	tx := newTestTx(t, newTestAddr(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 500000)), 100000)
+
+	// the msgs of a write-heavy tx write a 1000 byte value
+	writeValue := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		ctx.KVStore(app.GetKey(feetypes.StoreKey)).Set([]byte("k"), bytes.Repeat([]byte{1}, 1000))
+		return ctx, nil
+	}
+	gasUsed := func(rentRate uint64) uint64 {
+		app.setFeeParams(ctx, func(p *feetypes.FeeParams) { p.StateRentRate = rentRate })
+
+		ctx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		_, err := NewStateRentDecorator(app.feeKeeper).AnteHandle(ctx, tx, false, writeValue)
+		require.NoError(t, err)
+
+		return ctx.GasMeter().GasConsumed()
+	}
+
+	// every byte of the value pays 5 gas of rent
+	require.Equal(t, gasUsed(0)+1000*5, gasUsed(5))
+}
@@ -0,0 +1,144 @@
+package app
+
+import (
+	"encoding/json"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+	feekeeper "github.com/marbar3778/fee/x/fee/keeper"
+)
+
+// DefaultConsensusParams defines the default Tendermint consensus params used
+// in testing.
+var DefaultConsensusParams = &abci.ConsensusParams{
+	Block: &abci.BlockParams{
+		MaxBytes: 200000,
+		MaxGas:   20000000,
+	},
+	Evidence: &tmproto.EvidenceParams{
+		MaxAgeNumBlocks: 302400,
+		MaxAgeDuration:  504 * time.Hour, // 3 weeks is the max duration
+		MaxBytes:        10000,
+	},
+	Validator: &tmproto.ValidatorParams{
+		PubKeyTypes: []string{
+			tmtypes.ABCIPubKeyTypeEd25519,
+		},
+	},
+}
+
+// EmptyAppOptions is a stub implementing AppOptions
+type EmptyAppOptions struct{}
+
+// Get implements AppOptions
+func (EmptyAppOptions) Get(o string) interface{} {
+	return nil
+}
+
+// Setup initializes a new App with the default genesis state. A Nop logger is
+// set in App.
+//
+// NOTE: This is solely to be used for testing purposes.
+func Setup(isCheckTx bool) *App {
+	db := dbm.NewMemDB()
+	app := New(log.NewNopLogger(), db, nil, true, map[int64]bool{}, DefaultNodeHome, 0, MakeEncodingConfig(), EmptyAppOptions{})
+	if !isCheckTx {
+		// init chain must be called to stop deliverState from being nil
+		app.InitChain(
+			abci.RequestInitChain{
+				Validators:      []abci.ValidatorUpdate{},
+				ConsensusParams: DefaultConsensusParams,
+				AppStateBytes:   mustMarshalGenesis(NewDefaultGenesisState(app.AppCodec())),
+			},
+		)
+	}
+
+	return app
+}
+
+// SetupWithGenesisAccounts initializes a new App with the provided genesis
+// accounts and balances, commits the genesis block and begins the next one.
+// The genesis state of any module can be replaced through modify.
+//
+// NOTE: This is solely to be used for testing purposes.
+func SetupWithGenesisAccounts(modify func(GenesisState), genAccs []authtypes.GenesisAccount, balances ...banktypes.Balance) *App {
+	db := dbm.NewMemDB()
+	app := New(log.NewNopLogger(), db, nil, true, map[int64]bool{}, DefaultNodeHome, 0, MakeEncodingConfig(), EmptyAppOptions{})
+	genesisState := NewDefaultGenesisState(app.AppCodec())
+
+	authGenesis := authtypes.NewGenesisState(authtypes.DefaultParams(), genAccs)
+	genesisState[authtypes.ModuleName] = app.AppCodec().MustMarshalJSON(authGenesis)
+
+	totalSupply := sdk.NewCoins()
+	for _, b := range balances {
+		totalSupply = totalSupply.Add(b.Coins...)
+	}
+
+	bankGenesis := banktypes.NewGenesisState(banktypes.DefaultGenesisState().Params, balances, totalSupply, []banktypes.Metadata{})
+	genesisState[banktypes.ModuleName] = app.AppCodec().MustMarshalJSON(bankGenesis)
+
+	if modify != nil {
+		modify(genesisState)
+	}
+
+	app.InitChain(
+		abci.RequestInitChain{
+			Validators:      []abci.ValidatorUpdate{},
+			ConsensusParams: DefaultConsensusParams,
+			AppStateBytes:   mustMarshalGenesis(genesisState),
+		},
+	)
+
+	app.Commit()
+	app.BeginBlock(abci.RequestBeginBlock{Header: tmproto.Header{Height: app.LastBlockHeight() + 1}})
+
+	return app
+}
+
+func mustMarshalGenesis(genesisState GenesisState) []byte {
+	stateBytes, err := json.MarshalIndent(genesisState, "", " ")
+	if err != nil {
+		panic(err)
+	}
+
+	return stateBytes
+}
+
+// FundAccount mints amounts and sends them to addr, keeping the total supply
+// consistent with the balances.
+//
+// NOTE: This is solely to be used for testing purposes.
+func FundAccount(app *App, ctx sdk.Context, addr sdk.AccAddress, amounts sdk.Coins) error {
+	if err := app.BankKeeper.MintCoins(ctx, minttypes.ModuleName, amounts); err != nil {
+		return err
+	}
+
+	return app.BankKeeper.SendCoinsFromModuleToAccount(ctx, minttypes.ModuleName, addr, amounts)
+}
+
+// FundModuleAccount mints amounts and sends them to the named module account.
+//
+// NOTE: This is solely to be used for testing purposes.
+func FundModuleAccount(app *App, ctx sdk.Context, name string, amounts sdk.Coins) error {
+	if err := app.BankKeeper.MintCoins(ctx, minttypes.ModuleName, amounts); err != nil {
+		return err
+	}
+
+	return app.BankKeeper.SendCoinsFromModuleToModule(ctx, minttypes.ModuleName, name, amounts)
+}
+
+// FeeKeeper returns the fee module keeper.
+//
+// NOTE: This is solely to be used for testing purposes.
+func (app *App) FeeKeeper() feekeeper.Keeper {
+	return app.feeKeeper
+}
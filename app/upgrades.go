@@ -0,0 +1,36 @@
+package app
+
+import (
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+
+	feekeeper "github.com/marbar3778/fee/x/fee/keeper"
+	feetypes "github.com/marbar3778/fee/x/fee/types"
+)
+
+// FeeUpgradeName is the name of the upgrade adding the fee module to a
+// running chain.
+const FeeUpgradeName = "add-fee"
+
+// registerUpgradeHandlers registers the fee module upgrade: it adds the fee
+// store, seeds the default params and excludes the standing fee collector
+// balance from burns. It must be called before the latest version is loaded.
+func (app *App) registerUpgradeHandlers() {
+	app.UpgradeKeeper.SetUpgradeHandler(FeeUpgradeName, func(ctx sdk.Context, plan upgradetypes.Plan) {
+		if err := feekeeper.InitParamsOnUpgrade(ctx, app.feeKeeper, feetypes.DefaultParams()); err != nil {
+			panic(err)
+		}
+		feekeeper.SnapshotCollectorBalance(ctx, app.feeKeeper)
+	})
+
+	upgradeInfo, err := app.UpgradeKeeper.ReadUpgradeInfoFromDisk()
+	if err != nil {
+		panic(err)
+	}
+
+	if upgradeInfo.Name == FeeUpgradeName && !app.UpgradeKeeper.IsSkipHeight(upgradeInfo.Height) {
+		storeUpgrades := storetypes.StoreUpgrades{Added: []string{feetypes.StoreKey}}
+		app.SetStoreLoader(upgradetypes.UpgradeStoreLoader(upgradeInfo.Height, &storeUpgrades))
+	}
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	feetypes "github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestFeeUpgradeExcludesCollectorBalanceFromBurns(t *testing.T) {
+	app, ctx := setupAnte(t)
+	app.setFeeParams(ctx, func(p *feetypes.FeeParams) { p.BurnRate = sdk.NewDecWithPrec(5, 1) })
+	collector := app.AccountKeeper.GetModuleAddress(authtypes.FeeCollectorName)
+
+	// fees collected before the upgrade are never burned
+	require.NoError(t, FundModuleAccount(app, ctx, authtypes.FeeCollectorName, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000))))
+	app.UpgradeKeeper.ApplyUpgrade(ctx, upgradetypes.Plan{Name: FeeUpgradeName, Height: ctx.BlockHeight()})
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000)), app.feeKeeper.GetExcludedCollectorBalance(ctx))
+
+	require.NoError(t, FundModuleAccount(app, ctx, authtypes.FeeCollectorName, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 200))))
+	burned, err := app.feeKeeper.BurnFees(ctx)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100)), burned)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1100)), app.BankKeeper.GetAllBalances(ctx, collector))
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/marbar3778/fee/x/fee"
+	feetypes "github.com/marbar3778/fee/x/fee/types"
+)
+
+// ValidateFeeGenesisCmd returns validate-fee-genesis cobra Command.
+func ValidateFeeGenesisCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate-fee-genesis [file]",
+		Short: "Validate the fee module section of a genesis file",
+		Long: `Validate the fee module section of a genesis file on its own, without
+validating the rest of the app state. This lets operators check their fee
+params before the other modules' genesis is final.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			if err := fee.ValidateGenesisFile(clientCtx.JSONMarshaler, args[0]); err != nil {
+				return err
+			}
+
+			cmd.Printf("The %s genesis state in %s is valid\n", feetypes.ModuleName, args[0])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package fee
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/keeper"
+)
+
+// EndBlocker adjusts the dynamic base fee to the block's gas usage, records
+// it for the off-peak check, refunds unused gas, burns the configured
+// fraction of the remaining fee collector balance, finalizes the burns that
+// have been confirmed, emits the block's fee summary and prunes the fee stats
+// and allocations past their retention.
+func EndBlocker(ctx sdk.Context, k keeper.Keeper) {
+	gasUsed := ctx.BlockGasMeter().GasConsumed()
+	k.AdjustBaseFee(ctx, gasUsed)
+	k.SetLastBlockGas(ctx, gasUsed)
+
+	cacheCtx, write := ctx.CacheContext()
+	if err := k.RefundUnusedGas(cacheCtx); err != nil {
+		k.Logger(ctx).Error("failed to refund unused gas", "err", err)
+	} else {
+		write()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	}
+
+	cacheCtx, write = ctx.CacheContext()
+	if _, err := k.BurnFees(cacheCtx); err != nil {
+		k.Logger(ctx).Error("failed to burn fees", "err", err)
+	} else {
+		write()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	}
+
+	k.FinalizeBurns(ctx)
+	k.EmitBlockFeeSummary(ctx)
+	k.PruneBlockFeeStats(ctx)
+	k.PruneFeeAllocations(ctx)
+}
@@ -24,7 +24,75 @@ func GetQueryCmd(queryRoute string) *cobra.Command {
 		RunE:                       client.ValidateCmd,
 	}
 
+	cmd.AddCommand(CmdParams())
+	cmd.AddCommand(CmdGasPriceHistory())
+	cmd.AddCommand(CmdSuggestFeeBump())
+	cmd.AddCommand(CmdNetworkMinGasPrice())
+	cmd.AddCommand(CmdEstimateFees())
+	cmd.AddCommand(CmdEffectiveConfig())
+	cmd.AddCommand(CmdProjectSupply())
+	cmd.AddCommand(CmdFeeAllocation())
+	cmd.AddCommand(CmdPendingBurn())
+	cmd.AddCommand(CmdValidateParams())
+	cmd.AddCommand(CmdSimulateBurn())
+	cmd.AddCommand(CmdFeatures())
+	cmd.AddCommand(CmdAuthority())
+	cmd.AddCommand(CmdRequiredFeeBreakdown())
+	cmd.AddCommand(CmdConversionRates())
+	cmd.AddCommand(CmdTxCount())
+	cmd.AddCommand(CmdMinGasPriceInDenom())
+	cmd.AddCommand(CmdBurnStatus())
+	cmd.AddCommand(CmdEffectiveBurn())
+	cmd.AddCommand(CmdParamsProvenance())
+	cmd.AddCommand(CmdMaxGasForFee())
+	cmd.AddCommand(CmdDeflationRate())
+	cmd.AddCommand(CmdComputePriority())
+	cmd.AddCommand(CmdSweepableDust())
+	cmd.AddCommand(CmdFeeStatsByDenom())
 	// this line is used by starport scaffolding # 1
 
 	return cmd
 }
+
+// queryLegacy sends req to the given fee querier endpoint and decodes the
+// response into res.
+func queryLegacy(clientCtx client.Context, endpoint string, req, res interface{}) error {
+	var (
+		bz  []byte
+		err error
+	)
+
+	if req != nil {
+		bz, err = clientCtx.LegacyAmino.MarshalJSON(req)
+		if err != nil {
+			return err
+		}
+	}
+
+	route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, endpoint)
+	out, _, err := clientCtx.QueryWithData(route, bz)
+	if err != nil {
+		return err
+	}
+
+	return clientCtx.LegacyAmino.UnmarshalJSON(out, res)
+}
+
+const flagPrecision = "precision"
+
+// addPrecisionFlag adds the flag truncating decimal outputs for display.
+func addPrecisionFlag(cmd *cobra.Command) {
+	cmd.Flags().Int(flagPrecision, -1, "Number of decimal places to render gas prices with, full precision if negative")
+}
+
+// precisionFromFlags returns the requested display precision, or nil for full
+// precision.
+func precisionFromFlags(cmd *cobra.Command) (*uint32, error) {
+	precision, err := cmd.Flags().GetInt(flagPrecision)
+	if err != nil || precision < 0 {
+		return nil, err
+	}
+
+	p := uint32(precision)
+	return &p, nil
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func CmdFeeAllocation() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fee-allocation [tx-hash]",
+		Short: "Query how the fee of a past tx was allocated",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			req := types.QueryFeeAllocationRequest{TxHash: args[0]}
+
+			var res types.QueryFeeAllocationResponse
+			if err := queryLegacy(clientCtx, types.QueryFeeAllocation, req, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -0,0 +1,244 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func CmdProjectSupply() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project-supply [denom] [blocks-ahead] [avg-fee-per-block]",
+		Short: "Query the projected supply of a denom after burning fees for a number of blocks",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			blocksAhead, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			avgFee, ok := sdk.NewIntFromString(args[2])
+			if !ok {
+				return fmt.Errorf("invalid average fee per block: %s", args[2])
+			}
+
+			req := types.QueryProjectSupplyRequest{Denom: args[0], BlocksAhead: blocksAhead, AvgFeePerBlock: avgFee}
+
+			var res types.QueryProjectSupplyResponse
+			if err := queryLegacy(clientCtx, types.QueryProjectSupply, req, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdPendingBurn() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pending-burn",
+		Short: "Query the fees queued to be burned",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var res types.QueryPendingBurnResponse
+			if err := queryLegacy(clientCtx, types.QueryPendingBurn, nil, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+const flagBlocksPerDay = "blocks-per-day"
+
+func CmdSimulateBurn() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate-burn [avg-fee-per-block] [burn-rate]",
+		Short: "Query the projected burn per block and per day under a burn rate",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			avgFee, err := sdk.ParseCoinsNormalized(args[0])
+			if err != nil {
+				return err
+			}
+
+			burnRate, err := sdk.NewDecFromStr(args[1])
+			if err != nil {
+				return err
+			}
+
+			blocksPerDay, err := cmd.Flags().GetUint64(flagBlocksPerDay)
+			if err != nil {
+				return err
+			}
+
+			req := types.QuerySimulateBurnRequest{AvgFeePerBlock: avgFee, BurnRate: burnRate, BlocksPerDay: blocksPerDay}
+
+			var res types.QuerySimulateBurnResponse
+			if err := queryLegacy(clientCtx, types.QuerySimulateBurn, req, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	cmd.Flags().Uint64(flagBlocksPerDay, types.DefaultBlocksPerDay, "Number of blocks produced per day")
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+const flagBlocks = "blocks"
+
+func CmdDeflationRate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deflation-rate [denom]",
+		Short: "Query the yearly percentage of a denom's supply burned at the recent burn throughput",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			blocks, err := cmd.Flags().GetUint64(flagBlocks)
+			if err != nil {
+				return err
+			}
+
+			blocksPerDay, err := cmd.Flags().GetUint64(flagBlocksPerDay)
+			if err != nil {
+				return err
+			}
+
+			req := types.QueryDeflationRateRequest{Denom: args[0], Blocks: blocks, BlocksPerDay: blocksPerDay}
+
+			var res types.QueryDeflationRateResponse
+			if err := queryLegacy(clientCtx, types.QueryDeflationRate, req, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	cmd.Flags().Uint64(flagBlocks, uint64(types.MaxQueryHeightRange), fmt.Sprintf("Number of latest blocks whose burns are annualized, at most %d", types.MaxQueryHeightRange))
+	cmd.Flags().Uint64(flagBlocksPerDay, types.DefaultBlocksPerDay, "Number of blocks produced per day")
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdSweepableDust() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sweepable-dust",
+		Short: "Query the dust left in the fee module accounts that a dust sweep would move to the fee collector",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var res types.QuerySweepableDustResponse
+			if err := queryLegacy(clientCtx, types.QuerySweepableDust, nil, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdBurnStatus() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "burn-status",
+		Short: "Query whether fees are currently burned, at what rate and what the next burn takes",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var res types.QueryBurnStatusResponse
+			if err := queryLegacy(clientCtx, types.QueryBurnStatus, nil, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdEffectiveBurn() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "effective-burn [fee]",
+		Short: "Query the part of a fee that would be burned under the current params",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			fee, err := sdk.ParseCoinsNormalized(args[0])
+			if err != nil {
+				return err
+			}
+
+			req := types.QueryEffectiveBurnRequest{Fee: fee}
+
+			var res types.QueryEffectiveBurnResponse
+			if err := queryLegacy(clientCtx, types.QueryEffectiveBurn, req, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func CmdSuggestFeeBump() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "suggest-fee-bump [current-fee] [gas]",
+		Short: "Query a replacement fee for a stuck tx",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			currentFee, err := sdk.ParseCoinsNormalized(args[0])
+			if err != nil {
+				return err
+			}
+
+			gas, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			req := types.QuerySuggestFeeBumpRequest{CurrentFee: currentFee, Gas: gas}
+
+			var res types.QuerySuggestFeeBumpResponse
+			if err := queryLegacy(clientCtx, types.QuerySuggestFeeBump, req, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdNetworkMinGasPrice() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "network-min-gas-price",
+		Short: "Query the min gas price accepted by a supermajority of validators",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			precision, err := precisionFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			var res types.QueryNetworkMinGasPriceResponse
+			if err := queryLegacy(clientCtx, types.QueryNetworkMinGasPrice, types.QueryNetworkMinGasPriceRequest{Precision: precision}, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	addPrecisionFlag(cmd)
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdEstimateFees() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "estimate-fees [gas]",
+		Short: "Query the fee required for an amount of gas and how much of it is burned",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			gas, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			var res types.QueryEstimateFeesResponse
+			if err := queryLegacy(clientCtx, types.QueryEstimateFees, types.QueryEstimateFeesRequest{Gas: gas}, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdMaxGasForFee() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "max-gas-for-fee [fee] [denom]",
+		Short: "Query the most gas a tx paying a fee can declare at the min gas price in a denom",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			fee, err := sdk.ParseCoinsNormalized(args[0])
+			if err != nil {
+				return err
+			}
+
+			req := types.QueryMaxGasForFeeRequest{Fee: fee, Denom: args[1]}
+
+			var res types.QueryMaxGasForFeeResponse
+			if err := queryLegacy(clientCtx, types.QueryMaxGasForFee, req, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdComputePriority() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compute-priority [fee] [gas]",
+		Short: "Query the mempool priority a tx paying a fee for an amount of gas is assigned",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			fee, err := sdk.ParseCoinsNormalized(args[0])
+			if err != nil {
+				return err
+			}
+
+			gas, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			req := types.QueryComputePriorityRequest{Fee: fee, Gas: gas}
+
+			var res types.QueryComputePriorityResponse
+			if err := queryLegacy(clientCtx, types.QueryComputePriority, req, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdRequiredFeeBreakdown() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "required-fee-breakdown [gas] [msgs] [bytes] [sigs]",
+		Short: "Query the fee required from a tx, per fee component",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			values := make([]uint64, len(args))
+			for i, arg := range args {
+				if values[i], err = strconv.ParseUint(arg, 10, 64); err != nil {
+					return err
+				}
+			}
+
+			req := types.QueryRequiredFeeBreakdownRequest{Gas: values[0], Msgs: values[1], Bytes: values[2], Sigs: values[3]}
+
+			var res types.QueryRequiredFeeBreakdownResponse
+			if err := queryLegacy(clientCtx, types.QueryRequiredFeeBreakdown, req, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdConversionRates() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "conversion-rates",
+		Short: "Query the oracle conversion rates of the fee denoms",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var res types.QueryConversionRatesResponse
+			if err := queryLegacy(clientCtx, types.QueryConversionRates, nil, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdMinGasPriceInDenom() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "min-gas-price-in-denom [reference-denom]",
+		Short: "Query the min gas prices converted to a reference denom through the oracle",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			req := types.QueryMinGasPriceInDenomRequest{ReferenceDenom: args[0]}
+
+			var res types.QueryMinGasPriceInDenomResponse
+			if err := queryLegacy(clientCtx, types.QueryMinGasPriceInDenom, req, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func CmdEffectiveConfig() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "effective-config",
+		Short: "Query the fee configuration as currently enforced",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			precision, err := precisionFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			var res types.QueryEffectiveConfigResponse
+			if err := queryLegacy(clientCtx, types.QueryEffectiveConfig, types.QueryEffectiveConfigRequest{Precision: precision}, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	addPrecisionFlag(cmd)
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdParams() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "params",
+		Short: "Query the fee params",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var res types.QueryParamsResponse
+			if err := queryLegacy(clientCtx, types.QueryParams, nil, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdValidateParams() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate-params [params-file]",
+		Short: "Check whether the fee params in a JSON file would be accepted, without applying them",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var req types.QueryValidateParamsRequest
+			if err := clientCtx.LegacyAmino.UnmarshalJSON(bz, &req.Params); err != nil {
+				return err
+			}
+
+			var res types.QueryValidateParamsResponse
+			if err := queryLegacy(clientCtx, types.QueryValidateParams, req, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdFeatures() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "features",
+		Short: "Query which fee features are enabled",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var res types.QueryFeaturesResponse
+			if err := queryLegacy(clientCtx, types.QueryFeatures, nil, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdParamsProvenance() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "params-provenance",
+		Short: "Query the height, authority and proposal or call that last changed the fee params",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var res types.QueryParamsProvenanceResponse
+			if err := queryLegacy(clientCtx, types.QueryParamsProvenance, nil, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdAuthority() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "authority",
+		Short: "Query the address allowed to update the fee params",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var res types.QueryAuthorityResponse
+			if err := queryLegacy(clientCtx, types.QueryAuthority, nil, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
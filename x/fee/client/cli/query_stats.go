@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func CmdGasPriceHistory() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gas-price-history [from-height] [to-height] [denom]",
+		Short: "Query the average effective gas price paid per block",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			fromHeight, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			toHeight, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			precision, err := precisionFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			req := types.QueryGasPriceHistoryRequest{FromHeight: fromHeight, ToHeight: toHeight, Denom: args[2], Precision: precision}
+
+			var res types.QueryGasPriceHistoryResponse
+			if err := queryLegacy(clientCtx, types.QueryGasPriceHistory, req, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	addPrecisionFlag(cmd)
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdTxCount() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tx-count [from-height] [to-height]",
+		Short: "Query the number of txs that paid fees in a range of blocks",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			fromHeight, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			toHeight, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			req := types.QueryTxCountRequest{FromHeight: fromHeight, ToHeight: toHeight}
+
+			var res types.QueryTxCountResponse
+			if err := queryLegacy(clientCtx, types.QueryTxCount, req, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdFeeStatsByDenom() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fee-stats-by-denom [from-height] [to-height]",
+		Short: "Query the fees collected and burned per denom in a range of blocks",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			fromHeight, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			toHeight, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			req := types.QueryFeeStatsByDenomRequest{FromHeight: fromHeight, ToHeight: toHeight}
+
+			var res types.QueryFeeStatsByDenomResponse
+			if err := queryLegacy(clientCtx, types.QueryFeeStatsByDenom, req, &res); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
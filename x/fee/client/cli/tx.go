@@ -1,15 +1,24 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/spf13/cobra"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 
 	"github.com/cosmos/cosmos-sdk/client"
-	// "github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/marbar3778/fee/x/fee/types"
 )
 
+const flagAutoFee = "auto-fee"
+
 // GetTxCmd returns the transaction commands for this module
 func GetTxCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -20,7 +29,200 @@ func GetTxCmd() *cobra.Command {
 		RunE:                       client.ValidateCmd,
 	}
 
+	cmd.AddCommand(CmdUpdateParams())
+	cmd.AddCommand(CmdReclaimEscrow())
+	cmd.AddCommand(CmdSweepDust())
 	// this line is used by starport scaffolding # 1
 
 	return cmd
 }
+
+// AddAutoFeeFlag adds the --auto-fee flag to every tx command under cmd. With
+// it set the tx is simulated for its gas, no less than the MinGasPerByte the
+// chain requires, and pays the fee the required-fee-breakdown query returns
+// for it, the gas fee in the first denom
+// with a min gas price. Fees of msg fee calculators are not covered.
+func AddAutoFeeFlag(cmd *cobra.Command) {
+	for _, c := range cmd.Commands() {
+		AddAutoFeeFlag(c)
+	}
+
+	if cmd.RunE == nil || cmd.Flags().Lookup(flags.FlagFees) == nil {
+		return
+	}
+
+	cmd.Flags().Bool(flagAutoFee, false, "Simulate the tx for gas and pay the fee currently required for it")
+
+	runE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if autoFee, _ := cmd.Flags().GetBool(flagAutoFee); autoFee {
+			if err := setAutoFee(cmd, args, runE); err != nil {
+				return err
+			}
+		}
+
+		return runE(cmd, args)
+	}
+}
+
+// setAutoFee sets the gas and fees flags of cmd for --auto-fee.
+func setAutoFee(cmd *cobra.Command, args []string, runE func(*cobra.Command, []string) error) error {
+	for _, flag := range []string{flags.FlagFees, flags.FlagGasPrices, flags.FlagGenerateOnly, flags.FlagDryRun} {
+		if cmd.Flags().Changed(flag) {
+			return fmt.Errorf("--%s cannot be combined with --%s", flagAutoFee, flag)
+		}
+	}
+
+	clientCtx, err := client.GetClientTxContext(cmd)
+	if err != nil {
+		return err
+	}
+	if clientCtx.Offline {
+		return fmt.Errorf("--%s requires a node to query, it cannot be used offline", flagAutoFee)
+	}
+
+	gasAdjustment, err := cmd.Flags().GetFloat64(flags.FlagGasAdjustment)
+	if err != nil {
+		return err
+	}
+
+	var params types.QueryParamsResponse
+	if err := queryLegacy(clientCtx, types.QueryParams, nil, &params); err != nil {
+		return err
+	}
+
+	// The fee deduction only runs for txs paying a fee, and the size of the
+	// tx depends on its gas and fee, so the tx is simulated again with the
+	// gas and fee estimated until the estimate is covered by the tx simulated;
+	// that tx is then the one sent.
+	var (
+		gas uint64
+		fee sdk.Coins
+	)
+	for i := 0; ; i++ {
+		if err := cmd.Flags().Set(flags.FlagFees, fee.String()); err != nil {
+			return err
+		}
+		if gas > 0 {
+			if err := cmd.Flags().Set(flags.FlagGas, strconv.FormatUint(gas, 10)); err != nil {
+				return err
+			}
+		}
+
+		estimatedGas, estimatedFee, err := estimateFee(cmd, args, runE, clientCtx, gasAdjustment, params.Params.MinGasPerByte)
+		if err != nil {
+			return err
+		}
+		if gas > 0 && estimatedGas <= gas && fee.IsAllGTE(estimatedFee) {
+			return nil
+		}
+		if i == maxAutoFeeSimulations {
+			return fmt.Errorf("--%s found no fee covering the tx after %d simulations", flagAutoFee, i+1)
+		}
+
+		if estimatedGas > gas {
+			gas = estimatedGas
+		}
+		fee = estimatedFee
+	}
+}
+
+// estimateFee simulates the tx of the command and returns its gas, no less
+// than minGasPerByte gas per byte of the tx, and the fee the
+// required-fee-breakdown query returns for it.
+func estimateFee(cmd *cobra.Command, args []string, runE func(*cobra.Command, []string) error, clientCtx client.Context,
+	gasAdjustment float64, minGasPerByte uint64,
+) (uint64, sdk.Coins, error) {
+	simReq, simRes, err := simulateTx(cmd, args, runE, clientCtx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// the simulated tx carries empty signatures and public keys
+	sigs := len(simReq.Tx.AuthInfo.SignerInfos)
+	bytes := uint64(simReq.Tx.Size() + sigs*signatureSize)
+
+	gas := uint64(gasAdjustment * float64(simRes.GasInfo.GasUsed))
+	if minGas := bytes * minGasPerByte; gas < minGas {
+		gas = minGas
+	}
+
+	req := types.QueryRequiredFeeBreakdownRequest{
+		Gas:   gas,
+		Msgs:  uint64(len(simReq.Tx.Body.Messages)),
+		Bytes: bytes,
+		Sigs:  uint64(sigs),
+	}
+
+	var res types.QueryRequiredFeeBreakdownResponse
+	if err := queryLegacy(clientCtx, types.QueryRequiredFeeBreakdown, req, &res); err != nil {
+		return 0, nil, err
+	}
+
+	return gas, res.Breakdown.PayableFee(), nil
+}
+
+const (
+	// signatureSize is the most a secp256k1 signature and public key add to
+	// a simulated tx: the signature, the key with the tags and lengths of its
+	// fields, and a byte for each of the three lengths enclosing the key
+	// outgrowing a single byte varint
+	signatureSize = 64 + 2 + 2 + 33 + 3
+
+	// maxAutoFeeSimulations is the number of simulations past the first
+	// --auto-fee runs before giving up on a fee
+	maxAutoFeeSimulations = 3
+
+	simulatePath = "/cosmos.tx.v1beta1.Service/Simulate"
+)
+
+// simulateTx runs the tx command in dry run mode and returns the simulation
+// it ran. The client of clientCtx is wrapped to record it; the node flag is
+// hidden meanwhile, as the command would otherwise create a new client.
+func simulateTx(cmd *cobra.Command, args []string, runE func(*cobra.Command, []string) error, clientCtx client.Context) (
+	req txtypes.SimulateRequest, res txtypes.SimulateResponse, err error,
+) {
+	originalCtx := client.GetClientContextFromCmd(cmd)
+	defer client.SetCmdClientContext(cmd, originalCtx) // nolint: errcheck
+
+	recorder := &simulateRecorder{Client: clientCtx.Client}
+	if err := client.SetCmdClientContext(cmd, originalCtx.WithClient(recorder).WithSimulation(true)); err != nil {
+		return req, res, err
+	}
+
+	if node := cmd.Flags().Lookup(flags.FlagNode); node != nil && node.Changed {
+		node.Changed = false
+		defer func() { node.Changed = true }()
+	}
+
+	if err := runE(cmd, args); err != nil {
+		return req, res, err
+	}
+	if recorder.req == nil {
+		return req, res, fmt.Errorf("--%s is not supported by this command, it did not simulate the tx", flagAutoFee)
+	}
+
+	if err := req.Unmarshal(recorder.req); err != nil {
+		return req, res, err
+	}
+	if err := res.Unmarshal(recorder.res); err != nil {
+		return req, res, err
+	}
+
+	return req, res, nil
+}
+
+// simulateRecorder records the tx simulation queried through it.
+type simulateRecorder struct {
+	rpcclient.Client
+	req, res []byte
+}
+
+func (r *simulateRecorder) ABCIQueryWithOptions(ctx context.Context, path string, data tmbytes.HexBytes, opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	res, err := r.Client.ABCIQueryWithOptions(ctx, path, data, opts)
+	if err == nil && path == simulatePath && res.Response.IsOK() {
+		r.req, r.res = data, res.Response.Value
+	}
+
+	return res, err
+}
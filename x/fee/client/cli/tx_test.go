@@ -0,0 +1,89 @@
+package cli_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	clitestutil "github.com/cosmos/cosmos-sdk/testutil/cli"
+	"github.com/cosmos/cosmos-sdk/testutil/network"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	authcli "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	bankcli "github.com/cosmos/cosmos-sdk/x/bank/client/cli"
+	"github.com/marbar3778/fee/app"
+	"github.com/marbar3778/fee/x/fee/client/cli"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// newNetwork starts a single validator network of the app, its fee params
+// modified by modify.
+func newNetwork(t *testing.T, modify func(p *types.FeeParams)) *network.Network {
+	encCfg := app.MakeEncodingConfig()
+	cfg := network.DefaultConfig()
+	cfg.Codec = encCfg.Marshaler
+	cfg.TxConfig = encCfg.TxConfig
+	cfg.LegacyAmino = encCfg.Amino
+	cfg.InterfaceRegistry = encCfg.InterfaceRegistry
+	cfg.NumValidators = 1
+	cfg.AppConstructor = func(val network.Validator) servertypes.Application {
+		return app.New(
+			val.Ctx.Logger, dbm.NewMemDB(), nil, true, map[int64]bool{}, val.Ctx.Config.RootDir, 0, encCfg, app.EmptyAppOptions{},
+			baseapp.SetPruning(storetypes.NewPruningOptionsFromString(val.AppConfig.Pruning)),
+			baseapp.SetMinGasPrices(val.AppConfig.MinGasPrices),
+		)
+	}
+
+	genesis := types.DefaultGenesis()
+	modify(&genesis.Params.FeeParams)
+	cfg.GenesisState = app.NewDefaultGenesisState(encCfg.Marshaler)
+	cfg.GenesisState[types.ModuleName] = encCfg.Marshaler.MustMarshalJSON(genesis)
+
+	net := network.New(t, cfg)
+	t.Cleanup(net.Cleanup)
+	_, err := net.WaitForHeight(1)
+	require.NoError(t, err)
+
+	return net
+}
+
+func TestAutoFeeCoversGasPerByte(t *testing.T) {
+	// a bank send of a few hundred bytes needs more gas for its size than
+	// it uses
+	net := newNetwork(t, func(p *types.FeeParams) { p.MinGasPerByte = 1000 })
+	val := net.Validators[0]
+
+	cmd := bankcli.NewSendTxCmd()
+	cli.AddAutoFeeFlag(cmd)
+	out, err := clitestutil.ExecTestCLICmd(val.ClientCtx, cmd, []string{
+		val.Address.String(), sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()).String(), fmt.Sprintf("10%s", sdk.DefaultBondDenom),
+		fmt.Sprintf("--%s=%s", flags.FlagFrom, val.Address),
+		fmt.Sprintf("--%s=%s", flags.FlagBroadcastMode, flags.BroadcastBlock),
+		fmt.Sprintf("--%s=true", flags.FlagSkipConfirmation),
+		"--auto-fee",
+	})
+	require.NoError(t, err)
+
+	var res sdk.TxResponse
+	require.NoError(t, val.ClientCtx.JSONMarshaler.UnmarshalJSON(out.Bytes(), &res))
+	require.Equal(t, uint32(0), res.Code, res.RawLog)
+
+	out, err = clitestutil.ExecTestCLICmd(val.ClientCtx, authcli.QueryTxCmd(), []string{res.TxHash, fmt.Sprintf("--%s=json", "output")})
+	require.NoError(t, err)
+	require.NoError(t, val.ClientCtx.JSONMarshaler.UnmarshalJSON(out.Bytes(), &res))
+	tx, ok := res.GetTx().(*txtypes.Tx)
+	require.True(t, ok)
+
+	// the tx was accepted with the gas its size requires and pays the
+	// default 5stake per unit of it
+	gas := tx.AuthInfo.Fee.GasLimit
+	require.Greater(t, gas, uint64(res.GasUsed))
+	require.Equal(t, sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewIntFromUint64(gas*5))), tx.AuthInfo.Fee.Amount)
+}
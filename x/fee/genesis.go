@@ -1,6 +1,12 @@
 package fee
 
 import (
+	"encoding/json"
+	"fmt"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/marbar3778/fee/x/fee/keeper"
 	"github.com/marbar3778/fee/x/fee/types"
@@ -10,13 +16,59 @@ import (
 // state.
 func InitGenesis(ctx sdk.Context, k keeper.Keeper, genState types.GenesisState) {
 	// this line is used by starport scaffolding # genesis/module/init
+
+	params := genState.Params.FeeParams
+	k.SetParams(ctx, params)
+
+	// summarize the launch fee config for tooling watching the genesis block
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			params.EventType(types.EventTypeGenesis),
+			sdk.NewAttribute(types.AttributeKeyMinGasPrice, params.MinGasPrices().String()),
+			sdk.NewAttribute(types.AttributeKeyBurnRate, params.BurnRate.String()),
+			sdk.NewAttribute(types.AttributeKeyMinBurn, params.MinBurnAmount.String()),
+			sdk.NewAttribute(types.AttributeKeyFeeMode, params.FeeMode),
+		),
+	)
 }
 
 // ExportGenesis returns the capability module's exported genesis.
 func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
 	genesis := types.DefaultGenesis()
+	genesis.Params = types.GenesisParams{FeeParams: k.GetParams(ctx)}
 
 	// this line is used by starport scaffolding # genesis/module/export
 
 	return genesis
 }
+
+// ValidateGenesisFile validates the fee genesis state of the genesis file at
+// path on its own, params included, without validating the rest of the app
+// state.
+func ValidateGenesisFile(cdc codec.JSONMarshaler, path string) error {
+	genDoc, err := tmtypes.GenesisDocFromFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read genesis file %s: %w", path, err)
+	}
+
+	var appState map[string]json.RawMessage
+	if err := json.Unmarshal(genDoc.AppState, &appState); err != nil {
+		return fmt.Errorf("failed to unmarshal app state of %s: %w", path, err)
+	}
+
+	bz, ok := appState[types.ModuleName]
+	if !ok {
+		return fmt.Errorf("genesis file %s has no %s genesis state", path, types.ModuleName)
+	}
+
+	var genState types.GenesisState
+	if err := cdc.UnmarshalJSON(bz, &genState); err != nil {
+		return fmt.Errorf("failed to unmarshal %s genesis state: %w", types.ModuleName, err)
+	}
+
+	if err := genState.Validate(); err != nil {
+		return fmt.Errorf("invalid %s genesis state in %s: %w", types.ModuleName, path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package fee_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/app"
+	"github.com/marbar3778/fee/x/fee"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestGenesisImportExportParams(t *testing.T) {
+	feeApp := app.Setup(false)
+	ctx := feeApp.BaseApp.NewContext(false, tmproto.Header{})
+	k := feeApp.FeeKeeper()
+
+	params := types.DefaultParams()
+	params.BurnRate = sdk.NewDecWithPrec(25, 2)
+	params.AllowedDenoms = []string{"stake", "uatom"}
+	genState := types.GenesisState{Params: types.GenesisParams{FeeParams: params}}
+	require.NoError(t, genState.Validate())
+
+	fee.InitGenesis(ctx, k, genState)
+	require.Equal(t, params, k.GetParams(ctx))
+
+	exported := fee.ExportGenesis(ctx, k)
+	require.Equal(t, params, exported.Params.FeeParams)
+
+	// the params survive both encodings of the genesis state
+	cdc := feeApp.AppCodec()
+	var fromJSON types.GenesisState
+	require.NoError(t, cdc.UnmarshalJSON(cdc.MustMarshalJSON(exported), &fromJSON))
+	require.Equal(t, params, fromJSON.Params.FeeParams)
+
+	var fromBinary types.GenesisState
+	require.NoError(t, cdc.UnmarshalBinaryBare(cdc.MustMarshalBinaryBare(exported), &fromBinary))
+	require.Equal(t, params, fromBinary.Params.FeeParams)
+}
+
+func TestGenesisValidateParams(t *testing.T) {
+	require.NoError(t, types.DefaultGenesis().Validate())
+
+	params := types.DefaultParams()
+	params.BurnRate = sdk.NewDecWithPrec(15, 1)
+	genState := types.GenesisState{Params: types.GenesisParams{FeeParams: params}}
+	require.Error(t, genState.Validate())
+
+	// a genesis without params has no min gas price
+	require.Error(t, types.GenesisState{}.Validate())
+}
+
+func TestInitGenesisEmitsFeeConfig(t *testing.T) {
+	feeApp := app.Setup(false)
+	ctx := feeApp.BaseApp.NewContext(false, tmproto.Header{}).WithEventManager(sdk.NewEventManager())
+
+	params := types.DefaultParams()
+	params.BurnRate = sdk.NewDecWithPrec(25, 2)
+	params.MinBurnAmount = sdk.NewInt(10)
+	fee.InitGenesis(ctx, feeApp.FeeKeeper(), types.GenesisState{Params: types.GenesisParams{FeeParams: params}})
+
+	require.Equal(t, sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeGenesis,
+			sdk.NewAttribute(types.AttributeKeyMinGasPrice, "5.000000000000000000stake"),
+			sdk.NewAttribute(types.AttributeKeyBurnRate, "0.250000000000000000"),
+			sdk.NewAttribute(types.AttributeKeyMinBurn, "10"),
+			sdk.NewAttribute(types.AttributeKeyFeeMode, params.FeeMode),
+		),
+	}, ctx.EventManager().Events())
+}
+
+func TestValidateGenesisFileValidatesParams(t *testing.T) {
+	cdc := app.MakeEncodingConfig().Marshaler
+	writeGenesis := func(params types.FeeParams) string {
+		genState := types.GenesisState{Params: types.GenesisParams{FeeParams: params}}
+		appState, err := json.Marshal(map[string]json.RawMessage{types.ModuleName: cdc.MustMarshalJSON(&genState)})
+		require.NoError(t, err)
+
+		path := filepath.Join(t.TempDir(), "genesis.json")
+		require.NoError(t, (&tmtypes.GenesisDoc{ChainID: "fee-test", AppState: appState}).SaveAs(path))
+		return path
+	}
+
+	require.NoError(t, fee.ValidateGenesisFile(cdc, writeGenesis(types.DefaultParams())))
+
+	params := types.DefaultParams()
+	params.BurnRate = sdk.NewDecWithPrec(15, 1)
+	err := fee.ValidateGenesisFile(cdc, writeGenesis(params))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "burn rate must be between 0 and 1")
+}
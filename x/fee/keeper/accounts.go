@@ -0,0 +1,133 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// feeModuleAccounts are the module accounts fees pass through: the fee
+// collector receives collected fees and the fee module account burns them.
+var feeModuleAccounts = []string{
+	authtypes.FeeCollectorName,
+	types.ModuleName,
+}
+
+// GetFeeModuleAccounts returns the name and address of every module account
+// used by the fee module.
+func (k Keeper) GetFeeModuleAccounts(ctx sdk.Context) []types.FeeModuleAccount {
+	accounts := make([]types.FeeModuleAccount, 0, len(feeModuleAccounts))
+	for _, name := range feeModuleAccounts {
+		accounts = append(accounts, types.FeeModuleAccount{
+			Name:    name,
+			Address: k.accountKeeper.GetModuleAddress(name),
+		})
+	}
+
+	return accounts
+}
+
+// ReclaimEscrow sweeps the fee module account to the fee collector. The fee
+// module has no reserve-then-settle escrow; its own module account is the
+// only account fees are held in between deduction and settlement, while they
+// are burned. Should a failed burn ever leave coins there, the authority can
+// reclaim them.
+func (k Keeper) ReclaimEscrow(ctx sdk.Context, authority string) (sdk.Coins, error) {
+	if authority != k.authority {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "invalid authority; expected %s, got %s", k.authority, authority)
+	}
+
+	reclaimed := k.bankKeeper.GetAllBalances(ctx, k.accountKeeper.GetModuleAddress(types.ModuleName))
+	if reclaimed.Empty() {
+		return reclaimed, nil
+	}
+
+	if err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, authtypes.FeeCollectorName, reclaimed); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			k.GetParams(ctx).EventType(types.EventTypeReclaimEscrow),
+			sdk.NewAttribute(types.AttributeKeyAmount, reclaimed.String()),
+		),
+	)
+
+	return reclaimed, nil
+}
+
+// SweepableDust returns the dust SweepDust would move to the fee collector,
+// per fee module account other than the collector itself.
+func (k Keeper) SweepableDust(ctx sdk.Context) map[string]sdk.Coins {
+	threshold := k.GetParams(ctx).DustSweepThreshold
+	dust := make(map[string]sdk.Coins)
+	if threshold.Empty() {
+		return dust
+	}
+
+	for _, name := range feeModuleAccounts {
+		if name == authtypes.FeeCollectorName {
+			continue
+		}
+
+		accountDust := sdk.NewCoins()
+		for _, coin := range k.bankKeeper.GetAllBalances(ctx, k.accountKeeper.GetModuleAddress(name)) {
+			if coin.Amount.LT(threshold.AmountOf(coin.Denom)) {
+				accountDust = accountDust.Add(coin)
+			}
+		}
+		if !accountDust.Empty() {
+			dust[name] = accountDust
+		}
+	}
+
+	return dust
+}
+
+// SweepDust moves the balances below DustSweepThreshold left in the fee
+// module accounts, such as rounding remainders, to the fee collector, where
+// they are burned or distributed with the collected fees. Unlike
+// ReclaimEscrow it leaves balances above the threshold alone.
+func (k Keeper) SweepDust(ctx sdk.Context, authority string) (sdk.Coins, error) {
+	if authority != k.authority {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "invalid authority; expected %s, got %s", k.authority, authority)
+	}
+
+	dust := k.SweepableDust(ctx)
+	swept := sdk.NewCoins()
+	for _, name := range feeModuleAccounts {
+		accountDust, ok := dust[name]
+		if !ok {
+			continue
+		}
+
+		if err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, name, authtypes.FeeCollectorName, accountDust); err != nil {
+			return nil, err
+		}
+		swept = swept.Add(accountDust...)
+	}
+
+	if swept.Empty() {
+		return swept, nil
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			k.GetParams(ctx).EventType(types.EventTypeSweepDust),
+			sdk.NewAttribute(types.AttributeKeyAmount, swept.String()),
+		),
+	)
+
+	return swept, nil
+}
+
+func querySweepableDust(ctx sdk.Context, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	total := sdk.NewCoins()
+	for _, dust := range k.SweepableDust(ctx) {
+		total = total.Add(dust...)
+	}
+
+	return marshalResponse(legacyQuerierCdc, types.QuerySweepableDustResponse{Dust: total})
+}
@@ -0,0 +1,23 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestGetFeeModuleAccounts(t *testing.T) {
+	feeApp, ctx, k := setupKeeper(t)
+
+	accounts := k.GetFeeModuleAccounts(ctx)
+	names := make([]string, len(accounts))
+	for i, account := range accounts {
+		names[i] = account.Name
+		require.Equal(t, feeApp.AccountKeeper.GetModuleAddress(account.Name), account.Address)
+		require.NotNil(t, feeApp.AccountKeeper.GetModuleAccount(ctx, account.Name), account.Name)
+	}
+	require.Equal(t, []string{authtypes.FeeCollectorName, types.ModuleName}, names)
+}
@@ -0,0 +1,116 @@
+package keeper
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/marbar3778/fee/x/fee/types"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// ComputeFeeAllocation returns where fee would go when deducted under the
+// current params, see types.ComputeFeeAllocation. Burns KeepMinSupply skips
+// are collected instead.
+func (k Keeper) ComputeFeeAllocation(ctx sdk.Context, fee sdk.Coins) types.FeeAllocation {
+	params := k.GetParams(ctx)
+	allocation := types.ComputeFeeAllocation(params, fee)
+
+	burned := k.burnable(ctx, params, allocation.Burned)
+	allocation.Collected = allocation.Collected.Add(allocation.Burned.Sub(burned)...)
+	allocation.Burned = burned
+
+	return allocation
+}
+
+// RecordFeeAllocation stores how the fee paid by the current tx was allocated,
+// if RecordFeeAllocations is enabled.
+func (k Keeper) RecordFeeAllocation(ctx sdk.Context, allocation types.FeeAllocation) {
+	if !k.GetParams(ctx).RecordFeeAllocations {
+		return
+	}
+
+	hash := tmhash.Sum(ctx.TxBytes())
+	allocation.TxHash = strings.ToUpper(hex.EncodeToString(hash))
+	allocation.Height = ctx.BlockHeight()
+
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.FeeAllocationKey))
+	store.Set(hash, types.ModuleCdc.LegacyAmino.MustMarshalBinaryBare(allocation))
+
+	index := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.FeeAllocationHeightKey))
+	index.Set(append(sdk.Uint64ToBigEndian(uint64(allocation.Height)), hash...), []byte{})
+}
+
+// PruneFeeAllocations deletes the fee allocations recorded more than
+// StatsRetentionBlocks blocks before the current height.
+func (k Keeper) PruneFeeAllocations(ctx sdk.Context) {
+	retention := k.GetParams(ctx).StatsRetentionBlocks
+	if retention == 0 || ctx.BlockHeight() <= int64(retention) {
+		return
+	}
+
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.FeeAllocationKey))
+	index := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.FeeAllocationHeightKey))
+	iterator := index.Iterator(nil, sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight())-retention))
+	defer iterator.Close()
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	for _, key := range keys {
+		store.Delete(key[8:])
+		index.Delete(key)
+	}
+}
+
+// GetFeeAllocation returns the fee allocation recorded for the tx hash.
+func (k Keeper) GetFeeAllocation(ctx sdk.Context, hash []byte) (allocation types.FeeAllocation, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.FeeAllocationKey))
+	bz := store.Get(hash)
+	if bz == nil {
+		return allocation, false
+	}
+
+	types.ModuleCdc.LegacyAmino.MustUnmarshalBinaryBare(bz, &allocation)
+	return allocation, true
+}
+
+// FeeAllocation returns how the fee of a past tx was allocated. Only txs
+// included while RecordFeeAllocations was enabled have a record.
+func (k Keeper) FeeAllocation(ctx sdk.Context, req *types.QueryFeeAllocationRequest) (*types.QueryFeeAllocationResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+
+	hash, err := hex.DecodeString(req.TxHash)
+	if err != nil || len(hash) != tmhash.Size {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid tx hash: %s", req.TxHash)
+	}
+
+	allocation, found := k.GetFeeAllocation(ctx, hash)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrKeyNotFound, "no fee allocation recorded for tx %s", req.TxHash)
+	}
+
+	return &types.QueryFeeAllocationResponse{Allocation: allocation}, nil
+}
+
+func queryFeeAllocation(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryFeeAllocationRequest
+	if err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, err := k.FeeAllocation(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
@@ -0,0 +1,48 @@
+package keeper_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestFeeAllocationRecordedByTxHash(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	recipient := newTestAddr()
+	setParams(ctx, k, func(p *types.FeeParams) {
+		p.RecordFeeAllocations = true
+		p.FeeSplits = []types.FeeSplit{{Address: recipient.String(), Weight: sdk.NewDecWithPrec(2, 1)}}
+		p.DestinationPolicies = []types.DestinationPolicy{{Denom: "uburn", Destination: types.DestinationBurn}}
+	})
+
+	txBytes := []byte("fee allocation tx")
+	k.RecordFeeAllocation(ctx.WithTxBytes(txBytes), types.ComputeFeeAllocation(k.GetParams(ctx), coins("1000stake,30uburn")))
+
+	txHash := strings.ToUpper(hex.EncodeToString(tmhash.Sum(txBytes)))
+	res, err := k.FeeAllocation(ctx, &types.QueryFeeAllocationRequest{TxHash: txHash})
+	require.NoError(t, err)
+	require.Equal(t, types.FeeAllocation{
+		TxHash:     txHash,
+		Height:     ctx.BlockHeight(),
+		Collected:  coins("800stake"),
+		Burned:     coins("30uburn"),
+		Recipients: []types.FeeRecipient{{Address: recipient.String(), Amount: coins("200stake")}},
+	}, res.Allocation)
+
+	// the record is pruned with the stats of its block
+	setParams(ctx, k, func(p *types.FeeParams) { p.StatsRetentionBlocks = 10 })
+	k.PruneFeeAllocations(ctx.WithBlockHeight(ctx.BlockHeight() + 10))
+	_, err = k.FeeAllocation(ctx, &types.QueryFeeAllocationRequest{TxHash: txHash})
+	require.NoError(t, err)
+
+	k.PruneFeeAllocations(ctx.WithBlockHeight(ctx.BlockHeight() + 11))
+	_, err = k.FeeAllocation(ctx, &types.QueryFeeAllocationRequest{TxHash: txHash})
+	require.ErrorIs(t, err, sdkerrors.ErrKeyNotFound)
+}
@@ -0,0 +1,64 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// AllowanceCost returns what a tx with the required fee draws from the pool
+// of payer: the first way of paying required, see
+// RequiredFeeBreakdown.PayableOptions, the rest of the pool's allowance in the
+// current period covers. An error is returned when payer is in no pool or the
+// pool cannot cover the fee.
+func (k Keeper) AllowanceCost(ctx sdk.Context, params types.FeeParams, payer sdk.AccAddress, required types.RequiredFeeBreakdown) (types.FeeAllowancePool, sdk.Coins, error) {
+	pool, ok := params.AllowancePool(payer)
+	if !ok {
+		return pool, nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "%s is not a member of a fee allowance pool", payer)
+	}
+
+	spent := k.GetAllowanceSpent(ctx, pool)
+	for _, cost := range required.PayableOptions() {
+		if pool.Allowance.Sub(capCoins(spent, pool.Allowance)).IsAllGTE(cost) {
+			return pool, cost, nil
+		}
+	}
+
+	return pool, nil, sdkerrors.Wrapf(types.ErrAllowanceExhausted, "pool %s has spent %s of %s and cannot cover %s", pool.Name, spent, pool.Allowance, required.Total)
+}
+
+// DrawAllowance charges the required fee of a fee-less tx by payer to the
+// allowance of payer's pool in the current period.
+func (k Keeper) DrawAllowance(ctx sdk.Context, params types.FeeParams, payer sdk.AccAddress, required types.RequiredFeeBreakdown) error {
+	pool, cost, err := k.AllowanceCost(ctx, params, payer, required)
+	if err != nil {
+		return err
+	}
+	if cost.Empty() {
+		return nil
+	}
+
+	spent := types.AllowanceSpent{Period: pool.Period(ctx.BlockHeight()), Spent: k.GetAllowanceSpent(ctx, pool).Add(cost...)}
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.AllowanceSpentKey))
+	store.Set([]byte(pool.Name), types.ModuleCdc.LegacyAmino.MustMarshalBinaryBare(spent))
+	return nil
+}
+
+// GetAllowanceSpent returns the fees waived so far by pool in the current
+// period.
+func (k Keeper) GetAllowanceSpent(ctx sdk.Context, pool types.FeeAllowancePool) sdk.Coins {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.AllowanceSpentKey))
+	bz := store.Get([]byte(pool.Name))
+	if bz == nil {
+		return sdk.NewCoins()
+	}
+
+	var spent types.AllowanceSpent
+	types.ModuleCdc.LegacyAmino.MustUnmarshalBinaryBare(bz, &spent)
+	if spent.Period != pool.Period(ctx.BlockHeight()) {
+		return sdk.NewCoins()
+	}
+
+	return spent.Spent
+}
@@ -0,0 +1,119 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// GetBaseFee returns the dynamic base fee. It is empty when the base fee has
+// never been adjusted.
+func (k Keeper) GetBaseFee(ctx sdk.Context) sdk.DecCoins {
+	bz := ctx.KVStore(k.storeKey).Get(types.KeyPrefix(types.BaseFeeKey))
+	if bz == nil {
+		return sdk.NewDecCoins()
+	}
+
+	var baseFee sdk.DecCoins
+	types.ModuleCdc.LegacyAmino.MustUnmarshalBinaryBare(bz, &baseFee)
+	return baseFee
+}
+
+// SetBaseFee stores the dynamic base fee.
+func (k Keeper) SetBaseFee(ctx sdk.Context, baseFee sdk.DecCoins) {
+	ctx.KVStore(k.storeKey).Set(types.KeyPrefix(types.BaseFeeKey), types.ModuleCdc.LegacyAmino.MustMarshalBinaryBare(baseFee))
+}
+
+// AdjustBaseFee moves the base fee towards the block gas target, EIP-1559
+// style: base * (1 + (gasUsed - target) / target / changeDenominator). The
+// base fee never drops below the min gas prices in params, and is clamped
+// between MinBaseFee and MaxBaseFee.
+func (k Keeper) AdjustBaseFee(ctx sdk.Context, gasUsed uint64) {
+	params := k.GetParams(ctx)
+	if !params.BaseFeeEnabled || params.TargetBlockGas == 0 || params.BaseFeeChangeDenominator == 0 {
+		return
+	}
+
+	minGasPrices := params.MinGasPrices()
+
+	baseFee := k.GetBaseFee(ctx)
+	if baseFee.Empty() {
+		baseFee = minGasPrices
+	}
+
+	target := sdk.NewDec(int64(params.TargetBlockGas))
+	delta := sdk.NewDec(int64(gasUsed)).Sub(target).Quo(target).QuoInt64(int64(params.BaseFeeChangeDenominator))
+	multiplier := sdk.OneDec().Add(delta)
+
+	adjusted := sdk.NewDecCoins()
+	for _, price := range baseFee {
+		amt := sdk.MaxDec(price.Amount.Mul(multiplier), minGasPrices.AmountOf(price.Denom))
+		amt = sdk.MaxDec(amt, params.MinBaseFee.AmountOf(price.Denom))
+		if max := params.MaxBaseFee.AmountOf(price.Denom); max.IsPositive() {
+			amt = sdk.MinDec(amt, max)
+		}
+		if amt.IsPositive() {
+			adjusted = adjusted.Add(sdk.NewDecCoinFromDec(price.Denom, amt))
+		}
+	}
+
+	k.SetBaseFee(ctx, adjusted)
+}
+
+// GetLastBlockGas returns the gas used by the previous block.
+func (k Keeper) GetLastBlockGas(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.KeyPrefix(types.LastBlockGasKey))
+	if bz == nil {
+		return 0
+	}
+
+	return sdk.BigEndianToUint64(bz)
+}
+
+// SetLastBlockGas stores the gas used by the block being ended.
+func (k Keeper) SetLastBlockGas(ctx sdk.Context, gasUsed uint64) {
+	ctx.KVStore(k.storeKey).Set(types.KeyPrefix(types.LastBlockGasKey), sdk.Uint64ToBigEndian(gasUsed))
+}
+
+// EffectiveMinGasPrices resolves the min gas prices currently enforced: for
+// every denom in the params, the highest of the param price and the dynamic
+// base fee, reduced by the off-peak discount while the chain is off-peak, and
+// during CheckTx raised to the validator's local min gas price.
+func (k Keeper) EffectiveMinGasPrices(ctx sdk.Context) sdk.DecCoins {
+	params := k.GetParams(ctx)
+
+	var baseFee sdk.DecCoins
+	if params.BaseFeeEnabled {
+		baseFee = k.GetBaseFee(ctx)
+	}
+
+	var localMinGasPrices sdk.DecCoins
+	if ctx.IsCheckTx() {
+		localMinGasPrices = ctx.MinGasPrices()
+	}
+
+	discount := sdk.OneDec()
+	if params.IsOffPeak(k.GetLastBlockGas(ctx)) {
+		discount = discount.Sub(params.OffPeakDiscount)
+	}
+
+	effective := sdk.NewDecCoins()
+	for _, price := range params.MinGasPrices() {
+		amt := sdk.MaxDec(price.Amount, baseFee.AmountOf(price.Denom)).Mul(discount)
+		amt = sdk.MaxDec(amt, localMinGasPrices.AmountOf(price.Denom))
+		effective = effective.Add(sdk.NewDecCoinFromDec(price.Denom, amt))
+	}
+
+	return effective
+}
+
+// LoadSheddingMultiplier returns the factor the fee required for mempool
+// admission is multiplied by: LoadSheddingMultiplier while the previous block
+// was over the load shedding threshold, one otherwise.
+func (k Keeper) LoadSheddingMultiplier(ctx sdk.Context) sdk.Dec {
+	params := k.GetParams(ctx)
+	if !params.IsLoadShedding(k.GetLastBlockGas(ctx)) {
+		return sdk.OneDec()
+	}
+
+	return params.LoadSheddingMultiplier
+}
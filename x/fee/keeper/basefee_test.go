@@ -0,0 +1,48 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestOffPeakDiscount(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) {
+		p.TargetBlockGas = 1000000
+		p.OffPeakDiscount = sdk.NewDecWithPrec(2, 1)
+		p.OffPeakThreshold = sdk.NewDecWithPrec(5, 1)
+	})
+
+	k.SetLastBlockGas(ctx, 600000)
+	require.Equal(t, coins("5000stake"), k.RequiredFees(ctx, 1000))
+
+	// the previous block used less than half the target
+	k.SetLastBlockGas(ctx, 100000)
+	require.Equal(t, coins("4000stake"), k.RequiredFees(ctx, 1000))
+}
+
+func TestAdjustBaseFeeClampsAtMax(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	params := setParams(ctx, k, func(p *types.FeeParams) {
+		p.BaseFeeEnabled = true
+		p.TargetBlockGas = 1000000
+		p.MaxBaseFee = decCoins("8stake")
+	})
+
+	// full blocks raise the base fee an eighth every block
+	k.AdjustBaseFee(ctx, 2000000)
+	require.Equal(t, decCoins("5.625stake"), k.GetBaseFee(ctx))
+
+	for i := 0; i < 10; i++ {
+		k.AdjustBaseFee(ctx, 2000000)
+	}
+	require.Equal(t, decCoins("8stake"), k.GetBaseFee(ctx))
+	require.Equal(t, coins("8000stake"), k.RequiredFees(ctx, 1000))
+
+	params.MinBaseFee = decCoins("9stake")
+	require.Error(t, types.ValidateFee(params))
+}
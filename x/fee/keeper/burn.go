@@ -0,0 +1,121 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// BurnFees burns the BurnRate fraction of the fee collector's current balance.
+// Because the whole standing balance is considered, fees that are not
+// distributed keep getting burned down block after block. Fees collected
+// before the module was added, see SnapshotCollectorBalance, are left alone,
+// as are denoms at their KeepMinSupply.
+func (k Keeper) BurnFees(ctx sdk.Context) (sdk.Coins, error) {
+	params := k.GetParams(ctx)
+
+	collector := k.accountKeeper.GetModuleAddress(authtypes.FeeCollectorName)
+	balance := k.burnableCollectorBalance(ctx, k.bankKeeper.GetAllBalances(ctx, collector))
+	burn := collectorBurn(params, balance)
+
+	return k.burnFromCollector(ctx, params, burn)
+}
+
+// burnFromCollector burns coins held by the fee collector through the fee
+// module account and returns what was burned. Denoms whose supply the burn
+// would take below their KeepMinSupply are not burned and stay with the
+// collector.
+func (k Keeper) burnFromCollector(ctx sdk.Context, params types.FeeParams, coins sdk.Coins) (sdk.Coins, error) {
+	burn := k.burnable(ctx, params, coins)
+	if burn.Empty() {
+		return burn, nil
+	}
+
+	if err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, authtypes.FeeCollectorName, types.ModuleName, burn); err != nil {
+		return nil, err
+	}
+	if err := k.bankKeeper.BurnCoins(ctx, types.ModuleName, burn); err != nil {
+		return nil, err
+	}
+	k.logBurn(ctx, burn)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			params.EventType(types.EventTypeBurnFees),
+			sdk.NewAttribute(types.AttributeKeyAmount, burn.String()),
+		),
+	)
+
+	return burn, nil
+}
+
+// burnable returns the part of burn that may be burned at the current supply,
+// see FeeParams.BurnableAt. Every burn and burn estimate goes through it.
+func (k Keeper) burnable(ctx sdk.Context, params types.FeeParams, burn sdk.Coins) sdk.Coins {
+	if len(params.KeepMinSupply) == 0 {
+		return burn
+	}
+
+	return params.BurnableAt(k.bankKeeper.GetSupply(ctx).GetTotal(), burn)
+}
+
+// EstimateBurn returns the part of fees that would be burned under the
+// current params: the whole fee in denoms routed to burn, and the burn rate
+// applied to what reaches the fee collector. Denoms at their KeepMinSupply
+// burn nothing.
+func (k Keeper) EstimateBurn(ctx sdk.Context, fees sdk.Coins) sdk.Coins {
+	params := k.GetParams(ctx)
+	return k.burnable(ctx, params, estimateBurn(params, fees))
+}
+
+// estimateBurn returns the part of fees burned under params, see EstimateBurn.
+func estimateBurn(params types.FeeParams, fees sdk.Coins) sdk.Coins {
+	routed, collected := sdk.NewCoins(), sdk.NewCoins()
+	for _, fee := range fees {
+		switch params.Destination(fee.Denom) {
+		case types.DestinationBurn:
+			routed = routed.Add(fee)
+		case types.DestinationCollector:
+			collected = collected.Add(fee)
+		}
+	}
+
+	_, dust, collected := types.AllocateFeeSplits(params, collected)
+
+	return routed.Add(dust...).Add(collectorBurn(params, collected)...)
+}
+
+// collectorBurn returns the part of the fee collector balance burned under
+// the burn rate.
+func collectorBurn(params types.FeeParams, balance sdk.Coins) sdk.Coins {
+	burn := sdk.NewCoins()
+	if params.BurnRate.IsNil() || !params.BurnRate.IsPositive() {
+		return burn
+	}
+
+	for _, coin := range balance {
+		amt := burnAmount(params, coin.Amount)
+		if amt.IsPositive() {
+			burn = burn.Add(sdk.NewCoin(coin.Denom, amt))
+		}
+	}
+
+	return burn
+}
+
+// burnAmount returns how much of balance is burned under params. When the
+// burn rate truncates below MinBurnAmount the burn is rounded up to it (capped
+// at the balance) if RoundUpBurn is set, otherwise nothing is burned.
+func burnAmount(params types.FeeParams, balance sdk.Int) sdk.Int {
+	amt := balance.ToDec().Mul(params.BurnRate).TruncateInt()
+
+	if params.MinBurnAmount.IsNil() || !params.MinBurnAmount.IsPositive() || amt.GTE(params.MinBurnAmount) {
+		return amt
+	}
+
+	if !params.RoundUpBurn {
+		return sdk.ZeroInt()
+	}
+
+	return sdk.MinInt(params.MinBurnAmount, balance)
+}
@@ -0,0 +1,106 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// GetTotalBurned returns the cumulative amount of finalized fee burns.
+func (k Keeper) GetTotalBurned(ctx sdk.Context) sdk.Coins {
+	bz := ctx.KVStore(k.storeKey).Get(types.KeyPrefix(types.TotalBurnedKey))
+	if bz == nil {
+		return sdk.NewCoins()
+	}
+
+	var total sdk.Coins
+	types.ModuleCdc.LegacyAmino.MustUnmarshalBinaryBare(bz, &total)
+	return total
+}
+
+func (k Keeper) setTotalBurned(ctx sdk.Context, total sdk.Coins) {
+	ctx.KVStore(k.storeKey).Set(types.KeyPrefix(types.TotalBurnedKey), types.ModuleCdc.LegacyAmino.MustMarshalBinaryBare(total))
+}
+
+// GetPendingBurn returns the burns logged at the given height that are still
+// waiting for their confirmations.
+func (k Keeper) GetPendingBurn(ctx sdk.Context, height int64) sdk.Coins {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.PendingBurnKey))
+	bz := store.Get(sdk.Uint64ToBigEndian(uint64(height)))
+	if bz == nil {
+		return sdk.NewCoins()
+	}
+
+	var pending sdk.Coins
+	types.ModuleCdc.LegacyAmino.MustUnmarshalBinaryBare(bz, &pending)
+	return pending
+}
+
+// IteratePendingBurns iterates over the pending burns in ascending height
+// order until cb returns true.
+func (k Keeper) IteratePendingBurns(ctx sdk.Context, cb func(height int64, burned sdk.Coins) (stop bool)) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.PendingBurnKey))
+	iterator := store.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var burned sdk.Coins
+		types.ModuleCdc.LegacyAmino.MustUnmarshalBinaryBare(iterator.Value(), &burned)
+		if cb(int64(sdk.BigEndianToUint64(iterator.Key())), burned) {
+			break
+		}
+	}
+}
+
+// logBurn adds burned coins to the block fee stats and the burn log. Without
+// BurnConfirmations they count towards the total straight away, otherwise
+// they stay pending at the current height until FinalizeBurns confirms them.
+func (k Keeper) logBurn(ctx sdk.Context, burned sdk.Coins) {
+	if burned.Empty() {
+		return
+	}
+
+	if !ctx.IsCheckTx() {
+		k.RecordBurn(ctx, burned)
+	}
+
+	if k.GetParams(ctx).BurnConfirmations == 0 {
+		k.setTotalBurned(ctx, k.GetTotalBurned(ctx).Add(burned...))
+		return
+	}
+
+	pending := k.GetPendingBurn(ctx, ctx.BlockHeight()).Add(burned...)
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.PendingBurnKey))
+	store.Set(sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight())), types.ModuleCdc.LegacyAmino.MustMarshalBinaryBare(pending))
+}
+
+// FinalizeBurns moves the pending burns that have been confirmed by
+// BurnConfirmations blocks into the cumulative burn total.
+func (k Keeper) FinalizeBurns(ctx sdk.Context) {
+	confirmations := int64(k.GetParams(ctx).BurnConfirmations)
+
+	var (
+		heights   []int64
+		finalized = sdk.NewCoins()
+	)
+	k.IteratePendingBurns(ctx, func(height int64, burned sdk.Coins) bool {
+		if height+confirmations > ctx.BlockHeight() {
+			return true
+		}
+
+		heights = append(heights, height)
+		finalized = finalized.Add(burned...)
+		return false
+	})
+
+	if len(heights) == 0 {
+		return
+	}
+
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.PendingBurnKey))
+	for _, height := range heights {
+		store.Delete(sdk.Uint64ToBigEndian(uint64(height)))
+	}
+
+	k.setTotalBurned(ctx, k.GetTotalBurned(ctx).Add(finalized...))
+}
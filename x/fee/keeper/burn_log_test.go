@@ -0,0 +1,32 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestBurnFinalizedAfterConfirmations(t *testing.T) {
+	feeApp, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) {
+		p.BurnRate = sdk.NewDecWithPrec(1, 1)
+		p.BurnConfirmations = 3
+	})
+	fundCollector(t, feeApp, ctx, coins("1000stake"))
+
+	_, err := k.BurnFees(ctx)
+	require.NoError(t, err)
+
+	for height := ctx.BlockHeight(); height < ctx.BlockHeight()+3; height++ {
+		k.FinalizeBurns(ctx.WithBlockHeight(height))
+		require.True(t, k.GetTotalBurned(ctx).Empty(), "finalized at height %d", height)
+		require.Equal(t, coins("100stake"), k.GetPendingBurn(ctx, ctx.BlockHeight()))
+	}
+
+	k.FinalizeBurns(ctx.WithBlockHeight(ctx.BlockHeight() + 3))
+	require.Equal(t, coins("100stake"), k.GetTotalBurned(ctx))
+	require.True(t, k.GetPendingBurn(ctx, ctx.BlockHeight()).Empty())
+}
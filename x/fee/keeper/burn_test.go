@@ -0,0 +1,95 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestBurnFeesBurnsStandingBalance(t *testing.T) {
+	feeApp, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) { p.BurnRate = sdk.NewDecWithPrec(1, 1) })
+	fundCollector(t, feeApp, ctx, coins("1000stake"))
+
+	// every block burns a tenth of what the previous blocks left
+	for _, expected := range []string{"900stake", "810stake", "729stake"} {
+		burned, err := k.BurnFees(ctx)
+		require.NoError(t, err)
+		require.Equal(t, coins(expected), collectorBalance(feeApp, ctx))
+		require.False(t, burned.Empty())
+	}
+}
+
+func TestBurnFeesDisabled(t *testing.T) {
+	feeApp, ctx, k := setupKeeper(t)
+	fundCollector(t, feeApp, ctx, coins("1000stake"))
+
+	burned, err := k.BurnFees(ctx)
+	require.NoError(t, err)
+	require.True(t, burned.Empty())
+	require.Equal(t, coins("1000stake"), collectorBalance(feeApp, ctx))
+}
+
+func TestBurnFeesMinBurnAmount(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		roundUp  bool
+		expected string
+	}{
+		{"truncated burn skipped", false, "50stake"},
+		{"truncated burn rounded up", true, "40stake"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			feeApp, ctx, k := setupKeeper(t)
+			setParams(ctx, k, func(p *types.FeeParams) {
+				p.BurnRate = sdk.NewDecWithPrec(1, 2)
+				p.MinBurnAmount = sdk.NewInt(10)
+				p.RoundUpBurn = tc.roundUp
+			})
+			// a 1% burn of 50stake truncates to nothing
+			fundCollector(t, feeApp, ctx, coins("50stake"))
+
+			_, err := k.BurnFees(ctx)
+			require.NoError(t, err)
+			require.Equal(t, coins(tc.expected), collectorBalance(feeApp, ctx))
+		})
+	}
+}
+
+func TestBurnsSkippedNearMinSupply(t *testing.T) {
+	feeApp, ctx, k := setupKeeper(t)
+	fundCollector(t, feeApp, ctx, coins("1000stake"))
+	supply := feeApp.BankKeeper.GetSupply(ctx).GetTotal()
+	setParams(ctx, k, func(p *types.FeeParams) {
+		p.BurnRate = sdk.NewDecWithPrec(5, 1)
+		p.DestinationPolicies = []types.DestinationPolicy{{Denom: "stake", Destination: types.DestinationBurn}}
+		// 300stake may still be burned
+		p.KeepMinSupply = []types.MinSupply{{Denom: "stake", Amount: supply.AmountOf("stake").SubRaw(300)}}
+	})
+
+	// a burn within the margin goes through, one past it burns nothing
+	require.Equal(t, coins("300stake"), k.EstimateBurn(ctx, coins("300stake")))
+	require.True(t, k.EstimateBurn(ctx, coins("400stake")).Empty())
+
+	res, err := k.EffectiveBurn(ctx, &types.QueryEffectiveBurnRequest{Fee: coins("400stake")})
+	require.NoError(t, err)
+	require.True(t, res.Burned.Empty())
+
+	simulated, err := k.SimulateBurn(ctx, &types.QuerySimulateBurnRequest{AvgFeePerBlock: coins("400stake"), BurnRate: sdk.NewDecWithPrec(5, 1)})
+	require.NoError(t, err)
+	require.True(t, simulated.BurnPerBlock.Empty())
+
+	allocation := k.ComputeFeeAllocation(ctx, coins("400stake"))
+	require.True(t, allocation.Burned.Empty())
+	require.Equal(t, coins("400stake"), allocation.Collected)
+
+	// half of the collector balance is past the margin too
+	require.True(t, k.PendingBurn(ctx).NextBurn.Empty())
+	burned, err := k.BurnFees(ctx)
+	require.NoError(t, err)
+	require.True(t, burned.Empty())
+	require.Equal(t, coins("1000stake"), collectorBalance(feeApp, ctx))
+}
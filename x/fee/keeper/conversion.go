@@ -0,0 +1,173 @@
+package keeper
+
+import (
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/marbar3778/fee/x/fee/types"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// ConversionRate returns the value of one unit of denom in the base denom.
+// The oracle is queried at most once per denom per block, the rates fetched
+// are cached in the transient store so every node charges the same gas for a
+// cache hit.
+func (k Keeper) ConversionRate(ctx sdk.Context, denom string) (sdk.Dec, error) {
+	if k.extensions.oracle == nil {
+		return sdk.Dec{}, types.ErrNoOracle
+	}
+
+	store := prefix.NewStore(ctx.TransientStore(k.tStoreKey), types.KeyPrefix(types.ConversionRateKey))
+	if bz := store.Get([]byte(denom)); bz != nil {
+		var rate sdk.Dec
+		types.ModuleCdc.LegacyAmino.MustUnmarshalBinaryBare(bz, &rate)
+		return rate, nil
+	}
+
+	rate, err := k.extensions.oracle.GetExchangeRate(ctx, denom)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	store.Set([]byte(denom), types.ModuleCdc.LegacyAmino.MustMarshalBinaryBare(rate))
+	return rate, nil
+}
+
+// ConversionRates returns the oracle conversion rates of the fee denoms, the
+// denoms with a min gas price and the allowed denoms, sorted by denom.
+func (k Keeper) ConversionRates(ctx sdk.Context) (*types.QueryConversionRatesResponse, error) {
+	if k.extensions.oracle == nil {
+		return nil, types.ErrNoOracle
+	}
+
+	params := k.GetParams(ctx)
+	denoms := append([]string{}, params.AllowedDenoms...)
+	for _, gp := range params.MinGasPrices() {
+		denoms = append(denoms, gp.Denom)
+	}
+	sort.Strings(denoms)
+
+	// the oracle is called directly, queries must not fill the rate cache
+	// used while delivering the block
+	res := &types.QueryConversionRatesResponse{Height: ctx.BlockHeight(), Rates: []types.DenomRate{}}
+	for i, denom := range denoms {
+		if i > 0 && denoms[i-1] == denom {
+			continue
+		}
+
+		rate, err := k.extensions.oracle.GetExchangeRate(ctx, denom)
+		if err != nil {
+			return nil, err
+		}
+		res.Rates = append(res.Rates, types.DenomRate{Denom: denom, Rate: rate})
+	}
+
+	return res, nil
+}
+
+func queryConversionRates(ctx sdk.Context, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	res, err := k.ConversionRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
+
+// MinGasPriceInDenom converts every effective min gas price to the reference
+// denom through the oracle, for comparing prices set in different denoms.
+func (k Keeper) MinGasPriceInDenom(ctx sdk.Context, req *types.QueryMinGasPriceInDenomRequest) (*types.QueryMinGasPriceInDenomResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+	if err := sdk.ValidateDenom(req.ReferenceDenom); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	if k.extensions.oracle == nil {
+		return nil, types.ErrNoOracle
+	}
+
+	// the oracle is called directly, queries must not fill the rate cache
+	// used while delivering the block
+	refRate, err := k.extensions.oracle.GetExchangeRate(ctx, req.ReferenceDenom)
+	if err != nil {
+		return nil, err
+	}
+	if !refRate.IsPositive() {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "non-positive conversion rate for %s: %s", req.ReferenceDenom, refRate)
+	}
+
+	prices := []types.ReferencePrice{}
+	for _, gp := range k.EffectiveMinGasPrices(ctx) {
+		rate, err := k.extensions.oracle.GetExchangeRate(ctx, gp.Denom)
+		if err != nil {
+			return nil, err
+		}
+
+		prices = append(prices, types.ReferencePrice{GasPrice: gp, ReferenceGasPrice: gp.Amount.Mul(rate).Quo(refRate)})
+	}
+
+	return &types.QueryMinGasPriceInDenomResponse{ReferenceDenom: req.ReferenceDenom, Prices: prices}, nil
+}
+
+func queryMinGasPriceInDenom(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryMinGasPriceInDenomRequest
+	if err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, err := k.MinGasPriceInDenom(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
+
+// ValueBasedFee selects the part of the provided fee needed to cover
+// ValueGasPrice * gas. Denoms are drawn in the provided (sorted) order, each
+// converted through the oracle, until the target value is met; the last denom
+// used is only charged the amount still needed, rounded as ConversionRounding
+// sets. The ante check and the deduction both charge through here, so they
+// always round alike.
+func (k Keeper) ValueBasedFee(ctx sdk.Context, provided sdk.Coins, gas uint64) (sdk.Coins, error) {
+	params := k.GetParams(ctx)
+	remaining := params.ValueGasPrice.MulInt64(int64(gas))
+
+	charged := sdk.NewCoins()
+	for _, coin := range provided {
+		if !remaining.IsPositive() {
+			break
+		}
+
+		rate, err := k.ConversionRate(ctx, coin.Denom)
+		if err != nil {
+			return nil, err
+		}
+		if !rate.IsPositive() {
+			continue
+		}
+
+		value := coin.Amount.ToDec().Mul(rate)
+		if value.LTE(remaining) {
+			charged = charged.Add(coin)
+			remaining = remaining.Sub(value)
+			continue
+		}
+
+		if needed := params.RoundConversion(remaining.Quo(rate)); needed.IsPositive() {
+			charged = charged.Add(sdk.NewCoin(coin.Denom, needed))
+		}
+		remaining = sdk.ZeroDec()
+	}
+
+	if remaining.IsPositive() {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInsufficientFee, "fee %s is short of the required value by %s", provided, remaining)
+	}
+
+	return charged, nil
+}
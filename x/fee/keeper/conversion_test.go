@@ -0,0 +1,113 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestConversionRateCachedPerBlock(t *testing.T) {
+	feeApp, ctx, k := setupKeeper(t)
+	oracle := newMockOracle(map[string]sdk.Dec{"atom": sdk.NewDec(2), "stake": sdk.OneDec()})
+	k = withOracle(k, oracle)
+
+	for i := 0; i < 3; i++ {
+		for denom, expected := range oracle.rates {
+			rate, err := k.ConversionRate(ctx, denom)
+			require.NoError(t, err)
+			require.Equal(t, expected, rate)
+		}
+	}
+	require.Equal(t, map[string]int{"atom": 1, "stake": 1}, oracle.calls)
+
+	// the cache does not outlive the block
+	feeApp.EndBlock(abci.RequestEndBlock{Height: 2})
+	feeApp.Commit()
+	feeApp.BeginBlock(abci.RequestBeginBlock{Header: tmproto.Header{Height: 3, ChainID: testChainID}})
+	ctx = feeApp.BaseApp.NewContext(false, tmproto.Header{Height: 3, ChainID: testChainID})
+
+	_, err := k.ConversionRate(ctx, "atom")
+	require.NoError(t, err)
+	require.Equal(t, 2, oracle.calls["atom"])
+}
+
+func BenchmarkConversionRate(b *testing.B) {
+	_, ctx, k := setupKeeper(b)
+	oracle := newMockOracle(map[string]sdk.Dec{"atom": sdk.NewDec(2), "osmo": sdk.NewDecWithPrec(5, 1), "stake": sdk.OneDec()})
+	k = withOracle(k, oracle)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for denom := range oracle.rates {
+			if _, err := k.ConversionRate(ctx, denom); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	b.StopTimer()
+
+	for denom, calls := range oracle.calls {
+		if calls != 1 {
+			b.Fatalf("oracle called %d times for %s in a single block", calls, denom)
+		}
+	}
+}
+
+func TestConversionRatesQueriesOracle(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) { p.AllowedDenoms = []string{"atom", "stake"} })
+	oracle := newMockOracle(map[string]sdk.Dec{"atom": sdk.NewDec(2), "stake": sdk.OneDec(), "osmo": sdk.NewDec(3)})
+	k = withOracle(k, oracle)
+
+	// the fee denoms are listed even though no rate was fetched in the block,
+	// and the query does not fill the cache
+	res, err := k.ConversionRates(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []types.DenomRate{{Denom: "atom", Rate: sdk.NewDec(2)}, {Denom: "stake", Rate: sdk.OneDec()}}, res.Rates)
+
+	_, err = k.ConversionRate(ctx, "atom")
+	require.NoError(t, err)
+	require.Equal(t, 2, oracle.calls["atom"])
+}
+
+func TestMinGasPriceInDenomConvertsEachPrice(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) { p.Fee = decCoins("0.5atom,2stake") })
+	k = withOracle(k, newMockOracle(map[string]sdk.Dec{"atom": sdk.NewDec(8), "stake": sdk.NewDec(2)}))
+
+	res, err := k.MinGasPriceInDenom(ctx, &types.QueryMinGasPriceInDenomRequest{ReferenceDenom: "stake"})
+	require.NoError(t, err)
+	require.Equal(t, "stake", res.ReferenceDenom)
+	require.Equal(t, []types.ReferencePrice{
+		{GasPrice: sdk.NewDecCoinFromDec("atom", sdk.NewDecWithPrec(5, 1)), ReferenceGasPrice: sdk.NewDec(2)},
+		{GasPrice: sdk.NewDecCoinFromDec("stake", sdk.NewDec(2)), ReferenceGasPrice: sdk.NewDec(2)},
+	}, res.Prices)
+
+	_, err = k.MinGasPriceInDenom(ctx, &types.QueryMinGasPriceInDenomRequest{ReferenceDenom: "osmo"})
+	require.Error(t, err)
+}
+
+func TestValueBasedFeeRoundsAtFractionalBoundary(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	k = withOracle(k, newMockOracle(map[string]sdk.Dec{"atom": sdk.NewDec(3)}))
+
+	// 10 gas at a value of 1 needs 10/3 atom
+	for rounding, expected := range map[string]string{
+		types.RoundingCeil:  "4atom",
+		types.RoundingFloor: "3atom",
+	} {
+		setParams(ctx, k, func(p *types.FeeParams) {
+			p.ValueGasPrice = sdk.OneDec()
+			p.ConversionRounding = rounding
+		})
+
+		charged, err := k.ValueBasedFee(ctx, coins("100atom"), 10)
+		require.NoError(t, err, rounding)
+		require.Equal(t, coins(expected), charged, rounding)
+	}
+}
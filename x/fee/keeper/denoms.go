@@ -0,0 +1,109 @@
+package keeper
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// maxIntBitLen is the bit length of the largest sdk.Int.
+const maxIntBitLen = 255
+
+// denomUnit is a non-base unit of a denom, in terms of its base denom.
+type denomUnit struct {
+	base     string
+	exponent uint32
+}
+
+// NormalizeFee converts fee coins paid in a display or other non-base denom
+// unit of a fee denom to the base denom, e.g. 2atom becomes 2000000uatom.
+// Only the bank denom metadata of the fee denoms is read, see feeDenoms.
+// Coins already in a base denom, in a denom without metadata, or too large to
+// convert are left unchanged.
+func (k Keeper) NormalizeFee(ctx sdk.Context, fee sdk.Coins) sdk.Coins {
+	if fee.Empty() {
+		return fee
+	}
+
+	units := k.denomUnits(ctx, feeDenoms(k.GetParams(ctx)))
+	if len(units) == 0 {
+		return fee
+	}
+
+	normalized := sdk.NewCoins()
+	for _, coin := range fee {
+		unit, ok := units[coin.Denom]
+		if !ok {
+			normalized = normalized.Add(coin)
+			continue
+		}
+
+		amount := new(big.Int).Mul(coin.Amount.BigInt(), sdk.NewIntWithDecimal(1, int(unit.exponent)).BigInt())
+		if amount.BitLen() > maxIntBitLen {
+			normalized = normalized.Add(coin)
+			continue
+		}
+		normalized = normalized.Add(sdk.NewCoin(unit.base, sdk.NewIntFromBigInt(amount)))
+	}
+
+	return normalized
+}
+
+// feeDenoms returns the base denoms fees can be priced or paid in under
+// params: the min gas price denoms, the whitelisted denoms and the fallback
+// denom.
+func feeDenoms(params types.FeeParams) []string {
+	seen := make(map[string]bool)
+	var denoms []string
+	add := func(denom string) {
+		if denom != "" && !seen[denom] {
+			seen[denom] = true
+			denoms = append(denoms, denom)
+		}
+	}
+
+	for _, price := range params.Fee {
+		add(price.Denom)
+	}
+	for _, denom := range params.AllowedDenoms {
+		add(denom)
+	}
+	add(params.FallbackDenom)
+
+	return denoms
+}
+
+// denomUnits maps every non-base denom unit and alias in the bank denom
+// metadata of the given base denoms to its base denom. Units more than
+// types.MaxDenomExponent above the base are ignored.
+func (k Keeper) denomUnits(ctx sdk.Context, bases []string) map[string]denomUnit {
+	units := make(map[string]denomUnit)
+	for _, base := range bases {
+		md := k.bankKeeper.GetDenomMetaData(ctx, base)
+		if md.Base != base {
+			continue
+		}
+
+		var baseExponent uint32
+		for _, du := range md.DenomUnits {
+			if du.Denom == md.Base {
+				baseExponent = du.Exponent
+			}
+		}
+
+		for _, du := range md.DenomUnits {
+			if du.Denom == md.Base || du.Exponent <= baseExponent || du.Exponent-baseExponent > types.MaxDenomExponent {
+				continue
+			}
+
+			unit := denomUnit{base: md.Base, exponent: du.Exponent - baseExponent}
+			units[du.Denom] = unit
+			for _, alias := range du.Aliases {
+				units[alias] = unit
+			}
+		}
+	}
+
+	return units
+}
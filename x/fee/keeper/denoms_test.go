@@ -0,0 +1,33 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestNormalizeFee(t *testing.T) {
+	feeApp, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) { p.Fee = decCoins("0.01uatom,5stake") })
+
+	feeApp.BankKeeper.SetDenomMetaData(ctx, banktypes.Metadata{
+		Base: "uatom",
+		DenomUnits: []*banktypes.DenomUnit{
+			{Denom: "uatom", Exponent: 0},
+			{Denom: "atom", Exponent: 6, Aliases: []string{"ATOM"}},
+			{Denom: "gigaatom", Exponent: 42},
+		},
+	})
+	// not a fee denom, so its units are not resolved
+	feeApp.BankKeeper.SetDenomMetaData(ctx, banktypes.Metadata{
+		Base:       "uosmo",
+		DenomUnits: []*banktypes.DenomUnit{{Denom: "uosmo", Exponent: 0}, {Denom: "osmo", Exponent: 6}},
+	})
+
+	require.Equal(t, coins("2000000uatom"), k.NormalizeFee(ctx, coins("2atom")))
+	require.Equal(t, coins("3000000uatom"), k.NormalizeFee(ctx, coins("1ATOM,2atom")))
+	require.Equal(t, coins("1gigaatom,2osmo,10stake"), k.NormalizeFee(ctx, coins("1gigaatom,2osmo,10stake")))
+}
@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// RequiredFees returns the fees required by the effective min gas prices for
+// the given amount of gas.
+func (k Keeper) RequiredFees(ctx sdk.Context, gas uint64) sdk.Coins {
+	return types.ComputeRequiredFees(k.EffectiveMinGasPrices(ctx), gas)
+}
+
+// SpendableCoins returns the balance the account can spend on fees.
+func (k Keeper) SpendableCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	return k.bankKeeper.SpendableCoins(ctx, addr)
+}
+
+// ComputeTotalRequiredFee returns the fee required from tx: the gas fee at the
+// effective min gas prices, then the msg, byte and signature surcharges set
+// in params and the fees of the registered MsgFeeCalculators, raised to the
+// fee minimums, see RequiredFeeBreakdown.
+func (k Keeper) ComputeTotalRequiredFee(ctx sdk.Context, tx sdk.Tx) (types.RequiredFeeBreakdown, error) {
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok {
+		return types.RequiredFeeBreakdown{}, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "Tx must be a FeeTx")
+	}
+
+	var signatures int
+	if sigTx, ok := tx.(authsigning.SigVerifiableTx); ok {
+		sigs, err := sigTx.GetSignaturesV2()
+		if err != nil {
+			return types.RequiredFeeBreakdown{}, err
+		}
+		signatures = len(sigs)
+	}
+
+	params := k.GetParams(ctx)
+	breakdown := types.ComputeRequiredFeeBreakdown(
+		params,
+		k.EffectiveMinGasPrices(ctx),
+		feeTx.GetGas(),
+		len(tx.GetMsgs()),
+		len(ctx.TxBytes()),
+		signatures,
+	)
+
+	breakdown.Dynamic = k.DynamicMsgFees(ctx, tx.GetMsgs())
+	breakdown.Total = params.ApplyFeeMinimums(breakdown.Sum())
+
+	return breakdown, nil
+}
+
+// DynamicMsgFees returns the sum of the fees the registered
+// MsgFeeCalculators charge msgs. Msgs without a calculator add nothing.
+func (k Keeper) DynamicMsgFees(ctx sdk.Context, msgs []sdk.Msg) sdk.Coins {
+	fees := sdk.NewCoins()
+	if len(k.extensions.msgFeeCalculators) == 0 {
+		return fees
+	}
+
+	for _, msg := range msgs {
+		calculator, ok := k.extensions.msgFeeCalculators[types.MsgTypeURL(msg)]
+		if !ok {
+			continue
+		}
+
+		fees = fees.Add(calculator.ComputeMsgFee(ctx, msg)...)
+	}
+
+	return fees
+}
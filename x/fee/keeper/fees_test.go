@@ -0,0 +1,69 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/simapp/helpers"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/marbar3778/fee/app"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// signedTx returns a tx signed by a new key sending to n new addresses, and
+// its encoding.
+func signedTx(t *testing.T, n int, fee sdk.Coins, gas uint64) (sdk.Tx, []byte) {
+	priv := secp256k1.GenPrivKey()
+	from := sdk.AccAddress(priv.PubKey().Address())
+	msgs := make([]sdk.Msg, n)
+	for i := range msgs {
+		msgs[i] = banktypes.NewMsgSend(from, newTestAddr(), coins("1stake"))
+	}
+
+	txConfig := app.MakeEncodingConfig().TxConfig
+	tx, err := helpers.GenTx(txConfig, msgs, fee, gas, testChainID, []uint64{0}, []uint64{0}, priv)
+	require.NoError(t, err)
+	bz, err := txConfig.TxEncoder()(tx)
+	require.NoError(t, err)
+
+	return tx, bz
+}
+
+func TestComputeTotalRequiredFee(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) {
+		p.MsgFee = coins("100stake")
+		p.ByteFee = decCoins("0.5stake")
+		p.SignatureFee = coins("300stake")
+	})
+
+	tx, bz := signedTx(t, 2, coins("1stake"), 1000)
+	breakdown, err := k.ComputeTotalRequiredFee(ctx.WithTxBytes(bz), tx)
+	require.NoError(t, err)
+
+	bytesFee := sdk.NewCoins(sdk.NewInt64Coin("stake", int64(len(bz)+1)/2))
+	require.Equal(t, types.RequiredFeeBreakdown{
+		// 5stake per gas
+		Gas:        coins("5000stake"),
+		Msgs:       coins("200stake"),
+		Bytes:      bytesFee,
+		Signatures: coins("300stake"),
+		Dynamic:    sdk.NewCoins(),
+		Total:      coins("5500stake").Add(bytesFee...),
+	}, breakdown)
+}
+
+func TestRequiredFeesScaleDenomExponent(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) {
+		// 0.025atom per gas, with uatom the 6-decimal base denom
+		p.Fee = decCoins("0.025uatom")
+		p.DenomExponents = []types.DenomExponent{{Denom: "uatom", Exponent: 6}}
+	})
+
+	require.Equal(t, decCoins("25000uatom"), k.GetMinGasPrices(ctx))
+	require.Equal(t, coins("2500000uatom"), k.RequiredFees(ctx, 100))
+}
@@ -0,0 +1,26 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// SetDeliveringGenesisTxs marks whether the txs delivered in ctx are genesis
+// txs, such as create-validator gentxs. The app sets it around InitChain,
+// whose context the genesis txs are delivered in whatever the initial
+// height.
+func (k Keeper) SetDeliveringGenesisTxs(ctx sdk.Context, delivering bool) {
+	store := ctx.TransientStore(k.tStoreKey)
+	if !delivering {
+		store.Delete(types.KeyPrefix(types.GenesisTxsKey))
+		return
+	}
+
+	store.Set(types.KeyPrefix(types.GenesisTxsKey), []byte{1})
+}
+
+// IsDeliveringGenesisTxs reports whether the txs delivered in ctx are genesis
+// txs, see SetDeliveringGenesisTxs.
+func (k Keeper) IsDeliveringGenesisTxs(ctx sdk.Context) bool {
+	return ctx.TransientStore(k.tStoreKey).Has(types.KeyPrefix(types.GenesisTxsKey))
+}
@@ -4,4 +4,6 @@ import (
 	"github.com/marbar3778/fee/x/fee/types"
 )
 
+// The fee queries, pending-burn included, are served by the legacy querier
+// only, see NewQuerier; the gRPC Query service has no methods yet.
 var _ types.QueryServer = Keeper{}
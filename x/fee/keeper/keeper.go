@@ -7,23 +7,133 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 	"github.com/marbar3778/fee/x/fee/types"
 )
 
 type (
 	Keeper struct {
-		cdc      codec.Marshaler
-		storeKey sdk.StoreKey
-		memKey   sdk.StoreKey
+		cdc        codec.Marshaler
+		storeKey   sdk.StoreKey
+		memKey     sdk.StoreKey
+		tStoreKey  sdk.StoreKey
+		paramSpace paramtypes.Subspace
+
+		accountKeeper types.AccountKeeper
+		bankKeeper    types.BankKeeper
+		stakingKeeper types.StakingKeeper
+		distrKeeper   types.DistributionKeeper
+
+		// extensions are set once the keeper is created and shared by all
+		// its copies
+		extensions *extensions
+
+		// authority is the address allowed to update the params directly,
+		// the gov module account by default
+		authority string
+	}
+
+	// extensions are the oracle, fee denom hook and msg fee calculators the
+	// app plugs into the keeper. The app hands out copies of the keeper
+	// before setting them, so they are held behind a pointer.
+	extensions struct {
+		oracle    types.OracleKeeper
+		denomHook types.FeeDenomHook
+
+		// msgFeeCalculators compute the dynamic fee of msgs by type URL
+		msgFeeCalculators map[string]types.MsgFeeCalculator
 	}
 )
 
-func NewKeeper(cdc codec.Marshaler, storeKey, memKey sdk.StoreKey) *Keeper {
+func NewKeeper(
+	cdc codec.Marshaler, storeKey, memKey, tStoreKey sdk.StoreKey, paramSpace paramtypes.Subspace,
+	ak types.AccountKeeper, bk types.BankKeeper, sk types.StakingKeeper, dk types.DistributionKeeper,
+	authority string,
+) *Keeper {
+	// set KeyTable if it has not already been set
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
+	}
+
 	return &Keeper{
-		cdc:      cdc,
-		storeKey: storeKey,
-		memKey:   memKey,
+		cdc:           cdc,
+		storeKey:      storeKey,
+		memKey:        memKey,
+		tStoreKey:     tStoreKey,
+		paramSpace:    paramSpace,
+		accountKeeper: ak,
+		bankKeeper:    bk,
+		stakingKeeper: sk,
+		distrKeeper:   dk,
+		authority:     authority,
+		extensions:    &extensions{msgFeeCalculators: make(map[string]types.MsgFeeCalculator)},
+	}
+}
+
+// SetOracle sets the oracle used to convert fee denoms, for every copy of
+// the keeper.
+func (k Keeper) SetOracle(oracle types.OracleKeeper) Keeper {
+	if k.extensions.oracle != nil {
+		panic("cannot set fee oracle twice")
+	}
+
+	k.extensions.oracle = oracle
+	return k
+}
+
+// SetFeeDenomHook sets the hook deciding whether a payer may pay fees in a
+// denom, see IsFeeDenomSpendable, for every copy of the keeper.
+func (k Keeper) SetFeeDenomHook(hook types.FeeDenomHook) Keeper {
+	if k.extensions.denomHook != nil {
+		panic("cannot set fee denom hook twice")
+	}
+
+	k.extensions.denomHook = hook
+	return k
+}
+
+// IsFeeDenomSpendable reports whether payer may pay fees in denom, as decided
+// by the fee denom hook. Without a hook every denom is spendable.
+func (k Keeper) IsFeeDenomSpendable(ctx sdk.Context, payer sdk.AccAddress, denom string) bool {
+	if k.extensions.denomHook == nil {
+		return true
+	}
+
+	return k.extensions.denomHook.IsFeeDenomSpendable(ctx, payer, denom)
+}
+
+// SetMsgFeeCalculator registers the calculator charging msgs of the given type
+// URL a fee computed from their contents. A type URL takes a single
+// calculator, registering a second one fails.
+func (k Keeper) SetMsgFeeCalculator(typeURL string, calculator types.MsgFeeCalculator) error {
+	if _, ok := k.extensions.msgFeeCalculators[typeURL]; ok {
+		return fmt.Errorf("msg fee calculator for %s already set", typeURL)
 	}
+
+	k.extensions.msgFeeCalculators[typeURL] = calculator
+	return nil
+}
+
+// GetSubspace returns the raw fee param subspace, for tooling that needs
+// direct access. Prefer GetParams and UpdateParams, which keep the change
+// height, denom removals and provenance in sync.
+//
+// The subspace holds a single key, types.ParamStoreKeyfee, storing the whole
+// types.FeeParams. Get must be passed a pointer to it, Set takes either:
+//
+//	var params types.FeeParams
+//	k.GetSubspace().Get(ctx, types.ParamStoreKeyfee, &params)
+//
+// Get panics when passed the value instead of a pointer. Unlike GetIfExists
+// it also panics when the params have not been set. types.ParamStoreKeyburn
+// is not registered in the key table, and using it panics.
+func (k Keeper) GetSubspace() paramtypes.Subspace {
+	return k.paramSpace
+}
+
+// GetAuthority returns the address allowed to update the params.
+func (k Keeper) GetAuthority() string {
+	return k.authority
 }
 
 func (k Keeper) Logger(ctx sdk.Context) log.Logger {
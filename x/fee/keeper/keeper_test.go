@@ -0,0 +1,105 @@
+package keeper_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/marbar3778/fee/app"
+	"github.com/marbar3778/fee/x/fee/keeper"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+const testChainID = "fee-test"
+
+// setupKeeper returns an app past genesis, a deliver context at height 2 and
+// the fee keeper.
+func setupKeeper(t testing.TB) (*app.App, sdk.Context, keeper.Keeper) {
+	feeApp := app.SetupWithGenesisAccounts(nil, nil)
+	ctx := feeApp.BaseApp.NewContext(false, tmproto.Header{Height: 2, ChainID: testChainID})
+
+	return feeApp, ctx, feeApp.FeeKeeper()
+}
+
+// setParams updates the fee params with modify.
+func setParams(ctx sdk.Context, k keeper.Keeper, modify func(*types.FeeParams)) types.FeeParams {
+	params := k.GetParams(ctx)
+	modify(&params)
+	k.SetParams(ctx, params)
+
+	return params
+}
+
+func newTestAddr() sdk.AccAddress {
+	return sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+}
+
+func fundCollector(t *testing.T, feeApp *app.App, ctx sdk.Context, coins sdk.Coins) {
+	require.NoError(t, app.FundModuleAccount(feeApp, ctx, authtypes.FeeCollectorName, coins))
+}
+
+func collectorBalance(feeApp *app.App, ctx sdk.Context) sdk.Coins {
+	return feeApp.BankKeeper.GetAllBalances(ctx, feeApp.AccountKeeper.GetModuleAddress(authtypes.FeeCollectorName))
+}
+
+func coins(s string) sdk.Coins {
+	c, err := sdk.ParseCoinsNormalized(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+func decCoins(s string) sdk.DecCoins {
+	c, err := sdk.ParseDecCoins(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// mockOracle returns fixed conversion rates and counts its calls per denom.
+type mockOracle struct {
+	rates map[string]sdk.Dec
+	calls map[string]int
+}
+
+func newMockOracle(rates map[string]sdk.Dec) *mockOracle {
+	return &mockOracle{rates: rates, calls: make(map[string]int)}
+}
+
+func (o *mockOracle) GetExchangeRate(_ sdk.Context, denom string) (sdk.Dec, error) {
+	o.calls[denom]++
+
+	rate, ok := o.rates[denom]
+	if !ok {
+		return sdk.Dec{}, fmt.Errorf("no rate for %s", denom)
+	}
+
+	return rate, nil
+}
+
+// withOracle returns k converting fee denoms through oracle.
+func withOracle(k keeper.Keeper, oracle types.OracleKeeper) keeper.Keeper {
+	return k.SetOracle(oracle)
+}
+
+func TestGetSubspaceReadsParamsThroughPointer(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	params := setParams(ctx, k, func(p *types.FeeParams) { p.BurnRate = sdk.NewDecWithPrec(25, 2) })
+
+	var read types.FeeParams
+	k.GetSubspace().Get(ctx, types.ParamStoreKeyfee, &read)
+	require.Equal(t, params, read)
+
+	var value types.FeeParams
+	require.Panics(t, func() { k.GetSubspace().Get(ctx, types.ParamStoreKeyfee, value) })
+	require.Panics(t, func() { k.GetSubspace().Get(ctx, types.ParamStoreKeyburn, &read) })
+}
@@ -0,0 +1,92 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// InitParamsOnUpgrade seeds the fee params with defaults on a chain that adds
+// the fee module through an upgrade, where InitGenesis never runs. It is a
+// no-op when the params are already set. The app's "add-fee" upgrade handler
+// calls it, followed by SnapshotCollectorBalance.
+func InitParamsOnUpgrade(ctx sdk.Context, k Keeper, defaults types.FeeParams) error {
+	if k.HasParams(ctx) {
+		return nil
+	}
+
+	if err := types.ValidateFee(defaults); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	k.SetParams(ctx, defaults)
+	return nil
+}
+
+// SnapshotCollectorBalance records the fee collector balance at the upgrade
+// that adds the fee module, so fees collected before the module existed are
+// excluded from burns. Chains that do want the standing balance burned simply
+// don't call it. The snapshot shrinks as the balance is distributed, see
+// BurnFees.
+func SnapshotCollectorBalance(ctx sdk.Context, k Keeper) sdk.Coins {
+	collector := k.accountKeeper.GetModuleAddress(authtypes.FeeCollectorName)
+	balance := k.bankKeeper.GetAllBalances(ctx, collector)
+	k.setExcludedCollectorBalance(ctx, balance)
+
+	return balance
+}
+
+// GetExcludedCollectorBalance returns the part of the fee collector balance
+// that predates the fee module and is exempt from burns.
+func (k Keeper) GetExcludedCollectorBalance(ctx sdk.Context) sdk.Coins {
+	bz := ctx.KVStore(k.storeKey).Get(types.KeyPrefix(types.ExcludedCollectorBalanceKey))
+	if bz == nil {
+		return sdk.NewCoins()
+	}
+
+	var excluded sdk.Coins
+	types.ModuleCdc.LegacyAmino.MustUnmarshalBinaryBare(bz, &excluded)
+	return excluded
+}
+
+func (k Keeper) setExcludedCollectorBalance(ctx sdk.Context, excluded sdk.Coins) {
+	store := ctx.KVStore(k.storeKey)
+	if excluded.Empty() {
+		store.Delete(types.KeyPrefix(types.ExcludedCollectorBalanceKey))
+		return
+	}
+
+	store.Set(types.KeyPrefix(types.ExcludedCollectorBalanceKey), types.ModuleCdc.LegacyAmino.MustMarshalBinaryBare(excluded))
+}
+
+// burnableCollectorBalance returns the fee collector balance less the
+// pre-upgrade snapshot. The snapshot is first capped at the balance, since
+// whatever the distribution module paid out can't be excluded any more, and
+// stored back so it drains away with the old fees.
+func (k Keeper) burnableCollectorBalance(ctx sdk.Context, balance sdk.Coins) sdk.Coins {
+	excluded := k.GetExcludedCollectorBalance(ctx)
+	if excluded.Empty() {
+		return balance
+	}
+
+	remaining := capCoins(excluded, balance)
+	if !remaining.IsEqual(excluded) {
+		k.setExcludedCollectorBalance(ctx, remaining)
+	}
+
+	return balance.Sub(remaining)
+}
+
+// capCoins caps every coin in coins at its amount in limit.
+func capCoins(coins, limit sdk.Coins) sdk.Coins {
+	capped := sdk.NewCoins()
+	for _, coin := range coins {
+		amt := sdk.MinInt(coin.Amount, limit.AmountOf(coin.Denom))
+		if amt.IsPositive() {
+			capped = capped.Add(sdk.NewCoin(coin.Denom, amt))
+		}
+	}
+
+	return capped
+}
@@ -0,0 +1,29 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/app"
+	"github.com/marbar3778/fee/x/fee/keeper"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestInitParamsOnUpgrade(t *testing.T) {
+	// without InitChain the fee module never ran its genesis
+	feeApp := app.Setup(true)
+	ctx := feeApp.BaseApp.NewContext(true, tmproto.Header{Height: 10})
+	k := feeApp.FeeKeeper()
+	require.False(t, k.HasParams(ctx))
+
+	require.NoError(t, keeper.InitParamsOnUpgrade(ctx, k, types.DefaultParams()))
+	require.Equal(t, types.DefaultParams(), k.GetParams(ctx))
+
+	// params already set are left alone
+	custom := setParams(ctx, k, func(p *types.FeeParams) { p.BurnRate = sdk.NewDecWithPrec(3, 1) })
+	require.NoError(t, keeper.InitParamsOnUpgrade(ctx, k, types.DefaultParams()))
+	require.Equal(t, custom, k.GetParams(ctx))
+}
@@ -0,0 +1,21 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// ReclaimEscrow sweeps the fee module account to the fee collector, see
+// Keeper.ReclaimEscrow.
+func (k msgServer) ReclaimEscrow(goCtx context.Context, msg *types.MsgReclaimEscrow) (*types.MsgReclaimEscrowResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	reclaimed, err := k.Keeper.ReclaimEscrow(ctx, msg.Authority)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgReclaimEscrowResponse{Reclaimed: reclaimed}, nil
+}
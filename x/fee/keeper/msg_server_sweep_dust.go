@@ -0,0 +1,21 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// SweepDust moves the dust left in the fee module accounts to the fee
+// collector, see Keeper.SweepDust.
+func (k msgServer) SweepDust(goCtx context.Context, msg *types.MsgSweepDust) (*types.MsgSweepDustResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	swept, err := k.Keeper.SweepDust(ctx, msg.Authority)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSweepDustResponse{Swept: swept}, nil
+}
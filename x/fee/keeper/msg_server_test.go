@@ -0,0 +1,110 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/marbar3778/fee/app"
+	"github.com/marbar3778/fee/x/fee"
+	"github.com/marbar3778/fee/x/fee/keeper"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestMsgUpdateParamsRateLimited(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	params := setParams(ctx, k, func(p *types.FeeParams) { p.MinBlocksBetweenParamChanges = 10 })
+	msgServer := keeper.NewMsgServerImpl(k)
+
+	update := func(height int64, burnRate sdk.Dec) error {
+		params.BurnRate = burnRate
+		_, err := msgServer.UpdateParams(sdk.WrapSDKContext(ctx.WithBlockHeight(height)), types.NewMsgUpdateParams(k.GetAuthority(), params))
+		return err
+	}
+
+	require.NoError(t, update(5, sdk.NewDecWithPrec(1, 1)))
+
+	// the next change is allowed from height 15 on
+	require.ErrorIs(t, update(14, sdk.NewDecWithPrec(2, 1)), types.ErrParamChangeTooSoon)
+	require.Equal(t, sdk.NewDecWithPrec(1, 1), k.GetParams(ctx).BurnRate)
+
+	require.NoError(t, update(15, sdk.NewDecWithPrec(2, 1)))
+	require.Equal(t, sdk.NewDecWithPrec(2, 1), k.GetParams(ctx).BurnRate)
+}
+
+func TestMsgUpdateParamsInvalidSetLeavesParamsIntact(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	old := setParams(ctx, k, func(p *types.FeeParams) { p.BurnRate = sdk.NewDecWithPrec(1, 1) })
+	msgServer := keeper.NewMsgServerImpl(k)
+
+	// the burn rate change is valid, the whitelist leaves no priced denom
+	params := old
+	params.BurnRate = sdk.NewDecWithPrec(3, 1)
+	params.AllowedDenoms = []string{"atom"}
+	_, err := msgServer.UpdateParams(sdk.WrapSDKContext(ctx), types.NewMsgUpdateParams(k.GetAuthority(), params))
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+	require.Equal(t, old, k.GetParams(ctx))
+
+	_, found := k.GetLastParamChangeHeight(ctx)
+	require.False(t, found)
+	_, found = k.CurrentParamsProvenance(ctx)
+	require.False(t, found)
+}
+
+func TestMsgReclaimEscrow(t *testing.T) {
+	feeApp, ctx, k := setupKeeper(t)
+	msgServer := keeper.NewMsgServerImpl(k)
+	require.NoError(t, app.FundModuleAccount(feeApp, ctx, types.ModuleName, coins("70stake")))
+	escrow := feeApp.AccountKeeper.GetModuleAddress(types.ModuleName)
+
+	_, err := msgServer.ReclaimEscrow(sdk.WrapSDKContext(ctx), types.NewMsgReclaimEscrow(newTestAddr().String()))
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	require.Equal(t, coins("70stake"), feeApp.BankKeeper.GetAllBalances(ctx, escrow))
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	res, err := msgServer.ReclaimEscrow(sdk.WrapSDKContext(ctx), types.NewMsgReclaimEscrow(k.GetAuthority()))
+	require.NoError(t, err)
+	require.Equal(t, coins("70stake"), res.Reclaimed)
+	require.True(t, feeApp.BankKeeper.GetAllBalances(ctx, escrow).Empty())
+	require.Equal(t, coins("70stake"), collectorBalance(feeApp, ctx))
+	require.Contains(t, ctx.EventManager().Events(), sdk.NewEvent(
+		types.EventTypeReclaimEscrow,
+		sdk.NewAttribute(types.AttributeKeyAmount, "70stake"),
+	))
+}
+
+func TestMsgSweepDust(t *testing.T) {
+	feeApp, ctx, k := setupKeeper(t)
+	msgServer := keeper.NewMsgServerImpl(k)
+	setParams(ctx, k, func(p *types.FeeParams) { p.DustSweepThreshold = coins("10stake,100uatom,10uosmo") })
+
+	// seed dust, and a balance above the threshold, across the fee module
+	// accounts
+	require.NoError(t, app.FundModuleAccount(feeApp, ctx, types.ModuleName, coins("3stake,500uatom,7uosmo")))
+	fundCollector(t, feeApp, ctx, coins("2stake"))
+	escrow := feeApp.AccountKeeper.GetModuleAddress(types.ModuleName)
+
+	_, err := msgServer.SweepDust(sdk.WrapSDKContext(ctx), types.NewMsgSweepDust(newTestAddr().String()))
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	require.Equal(t, coins("3stake,500uatom,7uosmo"), feeApp.BankKeeper.GetAllBalances(ctx, escrow))
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	res, err := msgServer.SweepDust(sdk.WrapSDKContext(ctx), types.NewMsgSweepDust(k.GetAuthority()))
+	require.NoError(t, err)
+	require.Equal(t, coins("3stake,7uosmo"), res.Swept)
+	require.Equal(t, coins("500uatom"), feeApp.BankKeeper.GetAllBalances(ctx, escrow))
+	require.Equal(t, coins("5stake,7uosmo"), collectorBalance(feeApp, ctx))
+	require.Contains(t, ctx.EventManager().Events(), sdk.NewEvent(
+		types.EventTypeSweepDust,
+		sdk.NewAttribute(types.AttributeKeyAmount, "3stake,7uosmo"),
+	))
+
+	// the module handler routes the msg too
+	require.NoError(t, app.FundModuleAccount(feeApp, ctx, types.ModuleName, coins("4stake")))
+	_, err = fee.NewHandler(k)(ctx, types.NewMsgSweepDust(k.GetAuthority()))
+	require.NoError(t, err)
+	require.Equal(t, coins("500uatom"), feeApp.BankKeeper.GetAllBalances(ctx, escrow))
+	require.Equal(t, coins("9stake,7uosmo"), collectorBalance(feeApp, ctx))
+}
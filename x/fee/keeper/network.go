@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type powerPrice struct {
+	power int64
+	price sdk.Dec
+}
+
+// NetworkMinGasPrice estimates, per denom, the lowest gas price accepted by
+// validators holding at least 2/3 of the bonded power, based on the floors
+// reported in params. If the reporting validators hold less than 2/3 of the
+// power the highest reported floor is used. The result never drops below the
+// on-chain minimum gas prices.
+func (k Keeper) NetworkMinGasPrice(ctx sdk.Context) sdk.DecCoins {
+	params := k.GetParams(ctx)
+	totalPower := k.stakingKeeper.GetLastTotalPower(ctx).Int64()
+
+	byDenom := make(map[string][]powerPrice)
+	for _, vp := range params.ValidatorMinGasPrices {
+		valAddr, err := sdk.ValAddressFromBech32(vp.Validator)
+		if err != nil {
+			continue
+		}
+
+		val := k.stakingKeeper.Validator(ctx, valAddr)
+		if val == nil || !val.IsBonded() {
+			continue
+		}
+
+		for _, price := range vp.MinGasPrices {
+			byDenom[price.Denom] = append(byDenom[price.Denom], powerPrice{val.GetConsensusPower(), price.Amount})
+		}
+	}
+
+	threshold := sdk.NewInt(totalPower).MulRaw(2).ToDec().QuoInt64(3)
+
+	minGasPrices := params.MinGasPrices()
+	for denom, reports := range byDenom {
+		sort.SliceStable(reports, func(i, j int) bool { return reports[i].price.LT(reports[j].price) })
+
+		price := reports[len(reports)-1].price
+		var cumulative int64
+		for _, r := range reports {
+			cumulative += r.power
+			if sdk.NewDec(cumulative).GTE(threshold) {
+				price = r.price
+				break
+			}
+		}
+
+		if price.GT(minGasPrices.AmountOf(denom)) {
+			minGasPrices = setDecCoin(minGasPrices, sdk.NewDecCoinFromDec(denom, price))
+		}
+	}
+
+	return minGasPrices
+}
+
+// setDecCoin replaces the amount of coin's denom in coins.
+func setDecCoin(coins sdk.DecCoins, coin sdk.DecCoin) sdk.DecCoins {
+	out := sdk.NewDecCoins(coin)
+	for _, c := range coins {
+		if c.Denom != coin.Denom {
+			out = out.Add(c)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,55 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/marbar3778/fee/app"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// addBondedValidator stores a bonded validator with the given consensus power.
+func addBondedValidator(t *testing.T, feeApp *app.App, ctx sdk.Context, power int64) sdk.ValAddress {
+	pubKey := ed25519.GenPrivKey().PubKey()
+	valAddr := sdk.ValAddress(pubKey.Address())
+
+	val, err := stakingtypes.NewValidator(valAddr, pubKey, stakingtypes.Description{})
+	require.NoError(t, err)
+	val.Status = stakingtypes.Bonded
+	val.Tokens = sdk.TokensFromConsensusPower(power)
+	feeApp.StakingKeeper.SetValidator(ctx, val)
+
+	total := feeApp.StakingKeeper.GetLastTotalPower(ctx)
+	feeApp.StakingKeeper.SetLastTotalPower(ctx, total.AddRaw(power))
+
+	return valAddr
+}
+
+func TestNetworkMinGasPriceSupermajority(t *testing.T) {
+	feeApp, ctx, k := setupKeeper(t)
+
+	reports := []struct {
+		power int64
+		price string
+	}{
+		{40, "1stake"},
+		{30, "7stake"},
+		{30, "10stake"},
+	}
+	setParams(ctx, k, func(p *types.FeeParams) {
+		for _, r := range reports {
+			p.ValidatorMinGasPrices = append(p.ValidatorMinGasPrices, types.ValidatorMinGasPrice{
+				Validator:    addBondedValidator(t, feeApp, ctx, r.power).String(),
+				MinGasPrices: decCoins(r.price),
+			})
+		}
+	})
+
+	// validators with 70% of the power accept 7stake, the ones accepting
+	// 1stake only hold 40%
+	require.Equal(t, decCoins("7stake"), k.NetworkMinGasPrice(ctx))
+}
@@ -0,0 +1,167 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// GetParams returns the current fee params. A zero value is returned if the
+// params have not been set yet. It is the API for other modules in the same
+// binary to read the fee params with, rather than querying them.
+func (k Keeper) GetParams(ctx sdk.Context) (params types.FeeParams) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyfee, &params)
+	return params
+}
+
+// GetMinGasPrices returns the on-chain min gas prices in base denom units.
+// Unlike EffectiveMinGasPrices it ignores the dynamic base fee, the off-peak
+// discount and the node's local min gas prices.
+func (k Keeper) GetMinGasPrices(ctx sdk.Context) sdk.DecCoins {
+	return k.GetParams(ctx).MinGasPrices()
+}
+
+// GetBurnRate returns the fraction of the fee collector balance burned every
+// block, zero when burning is off.
+func (k Keeper) GetBurnRate(ctx sdk.Context) sdk.Dec {
+	rate := k.GetParams(ctx).BurnRate
+	if rate.IsNil() {
+		return sdk.ZeroDec()
+	}
+
+	return rate
+}
+
+// SetParams sets the fee params.
+func (k Keeper) SetParams(ctx sdk.Context, params types.FeeParams) {
+	k.paramSpace.Set(ctx, types.ParamStoreKeyfee, params)
+}
+
+// HasParams reports whether the fee params have been set.
+func (k Keeper) HasParams(ctx sdk.Context) bool {
+	return k.paramSpace.Has(ctx, types.ParamStoreKeyfee)
+}
+
+// UpdateParams validates and applies a complete new param set requested by
+// authority, rejecting it when authority is not the keeper's authority or the
+// params changed less than MinBlocksBetweenParamChanges blocks ago. The set is
+// validated as a whole, cross-field and chain constraints included as in
+// ValidateParams, before anything is written, so on error the current params
+// are left intact. Unlike SetParams it records the change height, denom
+// removals and provenance.
+func (k Keeper) UpdateParams(ctx sdk.Context, authority string, params types.FeeParams) error {
+	if authority != k.authority {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "invalid authority; expected %s, got %s", k.authority, authority)
+	}
+
+	if err := k.CheckParamChangeAllowed(ctx); err != nil {
+		return err
+	}
+
+	if err := k.CheckParams(params); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	old := k.GetParams(ctx)
+	k.SetParams(ctx, params)
+	k.SetLastParamChangeHeight(ctx, ctx.BlockHeight())
+	k.RecordDenomRemovals(ctx, old, params)
+	k.SetParamsProvenance(ctx, types.ParamsProvenance{Authority: authority, Source: types.ParamSourceAuthority})
+	return nil
+}
+
+// CheckParamChangeAllowed returns an error if the params changed less than
+// MinBlocksBetweenParamChanges blocks ago.
+func (k Keeper) CheckParamChangeAllowed(ctx sdk.Context) error {
+	window := k.GetParams(ctx).MinBlocksBetweenParamChanges
+	if window == 0 {
+		return nil
+	}
+
+	last, found := k.GetLastParamChangeHeight(ctx)
+	if !found {
+		return nil
+	}
+
+	if next := last + int64(window); ctx.BlockHeight() < next {
+		return sdkerrors.Wrapf(types.ErrParamChangeTooSoon, "last change at height %d, next allowed at height %d", last, next)
+	}
+
+	return nil
+}
+
+// GetLastParamChangeHeight returns the height the params last changed at
+// through UpdateParams or governance.
+func (k Keeper) GetLastParamChangeHeight(ctx sdk.Context) (int64, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.KeyPrefix(types.LastParamChangeKey))
+	if bz == nil {
+		return 0, false
+	}
+
+	return int64(sdk.BigEndianToUint64(bz)), true
+}
+
+// SetLastParamChangeHeight records the height the params changed at.
+func (k Keeper) SetLastParamChangeHeight(ctx sdk.Context, height int64) {
+	ctx.KVStore(k.storeKey).Set(types.KeyPrefix(types.LastParamChangeKey), sdk.Uint64ToBigEndian(uint64(height)))
+}
+
+// SetParamsProvenance records the change that set the current params, at the
+// current height.
+func (k Keeper) SetParamsProvenance(ctx sdk.Context, provenance types.ParamsProvenance) {
+	provenance.Height = ctx.BlockHeight()
+	ctx.KVStore(k.storeKey).Set(types.KeyPrefix(types.ParamsProvenanceKey), types.ModuleCdc.LegacyAmino.MustMarshalBinaryBare(provenance))
+}
+
+// CurrentParamsProvenance returns the change that set the current params.
+// Nothing is found for params unchanged since genesis.
+func (k Keeper) CurrentParamsProvenance(ctx sdk.Context) (provenance types.ParamsProvenance, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.KeyPrefix(types.ParamsProvenanceKey))
+	if bz == nil {
+		return provenance, false
+	}
+
+	types.ModuleCdc.LegacyAmino.MustUnmarshalBinaryBare(bz, &provenance)
+	return provenance, true
+}
+
+// RecordDenomRemovals records the current height as the removal height of
+// every denom the change from old to params drops from the whitelist, and
+// forgets the removal of denoms it lists again.
+func (k Keeper) RecordDenomRemovals(ctx sdk.Context, old, params types.FeeParams) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.DenomRemovalKey))
+	for _, denom := range params.RemovedDenoms(old) {
+		store.Set([]byte(denom), sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight())))
+	}
+	for _, denom := range params.AllowedDenoms {
+		store.Delete([]byte(denom))
+	}
+}
+
+// GetDenomRemovalHeight returns the height the denom was last removed from the
+// whitelist at.
+func (k Keeper) GetDenomRemovalHeight(ctx sdk.Context, denom string) (int64, bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.DenomRemovalKey))
+	bz := store.Get([]byte(denom))
+	if bz == nil {
+		return 0, false
+	}
+
+	return int64(sdk.BigEndianToUint64(bz)), true
+}
+
+// IsDenomAccepted reports whether fees may be paid in the given denom: it is
+// allowed by params, or was removed from the whitelist less than
+// GraceBlocksAfterDenomRemoval blocks ago.
+func (k Keeper) IsDenomAccepted(ctx sdk.Context, params types.FeeParams, denom string) bool {
+	if params.IsDenomAllowed(denom) {
+		return true
+	}
+	if params.GraceBlocksAfterDenomRemoval == 0 {
+		return false
+	}
+
+	removed, found := k.GetDenomRemovalHeight(ctx, denom)
+	return found && ctx.BlockHeight() < removed+int64(params.GraceBlocksAfterDenomRemoval)
+}
@@ -19,6 +19,81 @@ func NewQuerier(k Keeper, legacyQuerierCdc *codec.LegacyAmino) sdk.Querier {
 		)
 
 		switch path[0] {
+		case types.QueryGasPriceHistory:
+			res, err = queryGasPriceHistory(ctx, req, k, legacyQuerierCdc)
+
+		case types.QuerySuggestFeeBump:
+			res, err = querySuggestFeeBump(ctx, req, k, legacyQuerierCdc)
+
+		case types.QueryNetworkMinGasPrice:
+			res, err = queryNetworkMinGasPrice(ctx, req, k, legacyQuerierCdc)
+
+		case types.QueryEstimateFees:
+			res, err = queryEstimateFees(ctx, req, k, legacyQuerierCdc)
+
+		case types.QueryEffectiveConfig:
+			res, err = queryEffectiveConfig(ctx, req, k, legacyQuerierCdc)
+
+		case types.QueryParams:
+			res, err = queryParams(ctx, k, legacyQuerierCdc)
+
+		case types.QueryProjectSupply:
+			res, err = queryProjectSupply(ctx, req, k, legacyQuerierCdc)
+
+		case types.QueryFeeAllocation:
+			res, err = queryFeeAllocation(ctx, req, k, legacyQuerierCdc)
+
+		case types.QueryPendingBurn:
+			res, err = queryPendingBurn(ctx, k, legacyQuerierCdc)
+
+		case types.QueryValidateParams:
+			res, err = queryValidateParams(ctx, req, k, legacyQuerierCdc)
+
+		case types.QuerySimulateBurn:
+			res, err = querySimulateBurn(ctx, req, k, legacyQuerierCdc)
+
+		case types.QueryFeatures:
+			res, err = queryFeatures(ctx, k, legacyQuerierCdc)
+
+		case types.QueryAuthority:
+			res, err = queryAuthority(k, legacyQuerierCdc)
+
+		case types.QueryRequiredFeeBreakdown:
+			res, err = queryRequiredFeeBreakdown(ctx, req, k, legacyQuerierCdc)
+
+		case types.QueryConversionRates:
+			res, err = queryConversionRates(ctx, k, legacyQuerierCdc)
+
+		case types.QueryTxCount:
+			res, err = queryTxCount(ctx, req, k, legacyQuerierCdc)
+
+		case types.QueryMinGasPriceInDenom:
+			res, err = queryMinGasPriceInDenom(ctx, req, k, legacyQuerierCdc)
+
+		case types.QueryBurnStatus:
+			res, err = queryBurnStatus(ctx, k, legacyQuerierCdc)
+
+		case types.QueryEffectiveBurn:
+			res, err = queryEffectiveBurn(ctx, req, k, legacyQuerierCdc)
+
+		case types.QueryParamsProvenance:
+			res, err = queryParamsProvenance(ctx, k, legacyQuerierCdc)
+
+		case types.QueryMaxGasForFee:
+			res, err = queryMaxGasForFee(ctx, req, k, legacyQuerierCdc)
+
+		case types.QueryDeflationRate:
+			res, err = queryDeflationRate(ctx, req, k, legacyQuerierCdc)
+
+		case types.QueryComputePriority:
+			res, err = queryComputePriority(ctx, req, k, legacyQuerierCdc)
+
+		case types.QuerySweepableDust:
+			res, err = querySweepableDust(ctx, k, legacyQuerierCdc)
+
+		case types.QueryFeeStatsByDenom:
+			res, err = queryFeeStatsByDenom(ctx, req, k, legacyQuerierCdc)
+
 		// this line is used by starport scaffolding # 2
 		default:
 			err = sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown %s query endpoint: %s", types.ModuleName, path[0])
@@ -27,3 +102,26 @@ func NewQuerier(k Keeper, legacyQuerierCdc *codec.LegacyAmino) sdk.Querier {
 		return res, err
 	}
 }
+
+func marshalResponse(legacyQuerierCdc *codec.LegacyAmino, res interface{}) ([]byte, error) {
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+// unmarshalOptionalRequest decodes the request of a query whose parameters
+// are all optional, so it may be sent without any data.
+func unmarshalOptionalRequest(legacyQuerierCdc *codec.LegacyAmino, data []byte, req interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	if err := legacyQuerierCdc.UnmarshalJSON(data, req); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	return nil
+}
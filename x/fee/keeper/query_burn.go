@@ -0,0 +1,260 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/marbar3778/fee/x/fee/types"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// ProjectSupply projects the total supply of a denom BlocksAhead blocks from
+// now, assuming every block collects AvgFeePerBlock and burns it under the
+// current params.
+func (k Keeper) ProjectSupply(ctx sdk.Context, req *types.QueryProjectSupplyRequest) (*types.QueryProjectSupplyResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+	if err := sdk.ValidateDenom(req.Denom); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	if req.AvgFeePerBlock.IsNil() || req.AvgFeePerBlock.IsNegative() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "average fee per block cannot be negative")
+	}
+
+	supply := k.bankKeeper.GetSupply(ctx).GetTotal().AmountOf(req.Denom)
+	burnPerBlock := k.EstimateBurn(ctx, sdk.NewCoins(sdk.NewCoin(req.Denom, req.AvgFeePerBlock))).AmountOf(req.Denom)
+
+	projected := supply.Sub(burnPerBlock.Mul(sdk.NewIntFromUint64(req.BlocksAhead)))
+	if projected.IsNegative() {
+		projected = sdk.ZeroInt()
+	}
+
+	return &types.QueryProjectSupplyResponse{
+		CurrentSupply:   supply,
+		BurnPerBlock:    burnPerBlock,
+		ProjectedSupply: projected,
+	}, nil
+}
+
+func queryProjectSupply(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryProjectSupplyRequest
+	if err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, err := k.ProjectSupply(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
+
+// PendingBurn returns the fees queued to be burned: the fee collector balance
+// still subject to the burn rate, less any pre-upgrade balance, the part of it
+// the next burn takes, and the burns awaiting confirmation.
+func (k Keeper) PendingBurn(ctx sdk.Context) *types.QueryPendingBurnResponse {
+	params := k.GetParams(ctx)
+
+	accumulated := sdk.NewCoins()
+	if !params.BurnRate.IsNil() && params.BurnRate.IsPositive() {
+		balance := k.bankKeeper.GetAllBalances(ctx, k.accountKeeper.GetModuleAddress(authtypes.FeeCollectorName))
+		accumulated = balance.Sub(capCoins(k.GetExcludedCollectorBalance(ctx), balance))
+	}
+
+	unconfirmed := sdk.NewCoins()
+	k.IteratePendingBurns(ctx, func(_ int64, burned sdk.Coins) bool {
+		unconfirmed = unconfirmed.Add(burned...)
+		return false
+	})
+
+	return &types.QueryPendingBurnResponse{
+		Accumulated: accumulated,
+		NextBurn:    k.burnable(ctx, params, collectorBurn(params, accumulated)),
+		Unconfirmed: unconfirmed,
+	}
+}
+
+func queryPendingBurn(ctx sdk.Context, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	return marshalResponse(legacyQuerierCdc, k.PendingBurn(ctx))
+}
+
+// SimulateBurn projects the burn per block and per day if every block
+// collected AvgFeePerBlock under the given burn rate and otherwise current
+// params. No state is changed.
+func (k Keeper) SimulateBurn(ctx sdk.Context, req *types.QuerySimulateBurnRequest) (*types.QuerySimulateBurnResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+	if err := req.AvgFeePerBlock.Validate(); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	if req.BurnRate.IsNil() || req.BurnRate.IsNegative() || req.BurnRate.GT(sdk.OneDec()) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "burn rate must be between 0 and 1: %s", req.BurnRate)
+	}
+
+	blocksPerDay := req.BlocksPerDay
+	if blocksPerDay == 0 {
+		blocksPerDay = types.DefaultBlocksPerDay
+	}
+
+	params := k.GetParams(ctx)
+	params.BurnRate = req.BurnRate
+
+	perBlock := k.burnable(ctx, params, estimateBurn(params, req.AvgFeePerBlock))
+	perDay := sdk.NewCoins()
+	for _, coin := range perBlock {
+		perDay = perDay.Add(sdk.NewCoin(coin.Denom, coin.Amount.Mul(sdk.NewIntFromUint64(blocksPerDay))))
+	}
+
+	return &types.QuerySimulateBurnResponse{
+		BurnPerBlock: perBlock,
+		BurnPerDay:   perDay,
+	}, nil
+}
+
+// DeflationRate annualizes the burns recorded in the block fee stats of the
+// latest blocks, at most MaxQueryHeightRange of them, into the percentage of
+// the current supply of a denom burned per year.
+func (k Keeper) DeflationRate(ctx sdk.Context, req *types.QueryDeflationRateRequest) (*types.QueryDeflationRateResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+	if err := sdk.ValidateDenom(req.Denom); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	// like the other height range queries the window spans at most
+	// MaxQueryHeightRange blocks
+	blocks, blocksPerDay := req.Blocks, req.BlocksPerDay
+	if blocks == 0 || blocks > uint64(types.MaxQueryHeightRange) {
+		blocks = uint64(types.MaxQueryHeightRange)
+	}
+	if blocksPerDay == 0 {
+		blocksPerDay = types.DefaultBlocksPerDay
+	}
+
+	// the window ends at the current height and starts no earlier than the
+	// first block
+	toHeight := ctx.BlockHeight()
+	fromHeight := int64(1)
+	if toHeight > int64(blocks) {
+		fromHeight = toHeight - int64(blocks) + 1
+	}
+
+	res := &types.QueryDeflationRateResponse{
+		Supply:            k.bankKeeper.GetSupply(ctx).GetTotal().AmountOf(req.Denom),
+		Burned:            sdk.ZeroInt(),
+		AnnualizedPercent: sdk.ZeroDec(),
+	}
+	if toHeight < fromHeight {
+		return res, nil
+	}
+	res.Blocks = uint64(toHeight - fromHeight + 1)
+
+	k.IterateBlockFeeStats(ctx, fromHeight, toHeight, func(stats types.BlockFeeStats) bool {
+		res.Burned = res.Burned.Add(stats.Burned.AmountOf(req.Denom))
+		return false
+	})
+
+	if res.Supply.IsPositive() {
+		perYear := res.Burned.ToDec().MulInt64(int64(blocksPerDay) * 365).QuoInt64(int64(res.Blocks))
+		res.AnnualizedPercent = perYear.QuoInt(res.Supply).MulInt64(100)
+	}
+
+	return res, nil
+}
+
+func queryDeflationRate(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryDeflationRateRequest
+	if err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, err := k.DeflationRate(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
+
+func querySimulateBurn(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QuerySimulateBurnRequest
+	if err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, err := k.SimulateBurn(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
+
+// BurnStatus summarizes whether and how fees are currently burned.
+func (k Keeper) BurnStatus(ctx sdk.Context) *types.QueryBurnStatusResponse {
+	params := k.GetParams(ctx)
+
+	rate := sdk.ZeroDec()
+	if !params.BurnRate.IsNil() {
+		rate = params.BurnRate
+	}
+
+	enabled := rate.IsPositive()
+	for _, policy := range params.DestinationPolicies {
+		if policy.Destination == types.DestinationBurn {
+			enabled = true
+		}
+	}
+
+	return &types.QueryBurnStatusResponse{
+		Enabled:     enabled,
+		Rate:        rate,
+		Destination: k.accountKeeper.GetModuleAddress(types.ModuleName).String(),
+		PendingBurn: k.PendingBurn(ctx).NextBurn,
+	}
+}
+
+func queryBurnStatus(ctx sdk.Context, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	return marshalResponse(legacyQuerierCdc, k.BurnStatus(ctx))
+}
+
+// EffectiveBurn returns the part of a hypothetical fee that would be burned
+// under the current params, see EstimateBurn, per denom. Denoms routed to the
+// community pool burn nothing.
+func (k Keeper) EffectiveBurn(ctx sdk.Context, req *types.QueryEffectiveBurnRequest) (*types.QueryEffectiveBurnResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+	if err := req.Fee.Validate(); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+	}
+
+	burned := k.EstimateBurn(ctx, req.Fee)
+
+	denoms := make([]types.DenomBurn, len(req.Fee))
+	for i, coin := range req.Fee {
+		denoms[i] = types.DenomBurn{Denom: coin.Denom, Fee: coin.Amount, Burned: burned.AmountOf(coin.Denom)}
+	}
+
+	return &types.QueryEffectiveBurnResponse{Burned: burned, Denoms: denoms}, nil
+}
+
+func queryEffectiveBurn(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryEffectiveBurnRequest
+	if err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, err := k.EffectiveBurn(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
@@ -0,0 +1,136 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/app"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestProjectSupply(t *testing.T) {
+	feeApp, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) { p.BurnRate = sdk.NewDecWithPrec(25, 2) })
+	require.NoError(t, app.FundAccount(feeApp, ctx, newTestAddr(), coins("1000000stake")))
+	supply := feeApp.BankKeeper.GetSupply(ctx).GetTotal().AmountOf("stake")
+
+	// a quarter of 400stake is burned every block
+	res, err := k.ProjectSupply(ctx, &types.QueryProjectSupplyRequest{Denom: "stake", BlocksAhead: 100, AvgFeePerBlock: sdk.NewInt(400)})
+	require.NoError(t, err)
+	require.Equal(t, supply, res.CurrentSupply)
+	require.Equal(t, sdk.NewInt(100), res.BurnPerBlock)
+	require.Equal(t, supply.SubRaw(10000), res.ProjectedSupply)
+}
+
+func TestPendingBurnAccumulates(t *testing.T) {
+	feeApp, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) {
+		p.BurnRate = sdk.NewDecWithPrec(1, 2)
+		p.MinBurnAmount = sdk.NewInt(10)
+	})
+
+	// fees below the burn threshold keep accumulating
+	for _, expected := range []string{"400stake", "800stake"} {
+		fundCollector(t, feeApp, ctx, coins("400stake"))
+		_, err := k.BurnFees(ctx)
+		require.NoError(t, err)
+
+		res := k.PendingBurn(ctx)
+		require.Equal(t, coins(expected), res.Accumulated)
+		require.True(t, res.NextBurn.Empty())
+	}
+
+	// until the next burn reaches it
+	fundCollector(t, feeApp, ctx, coins("400stake"))
+	require.Equal(t, coins("12stake"), k.PendingBurn(ctx).NextBurn)
+}
+
+func TestSimulateBurn(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	before := k.GetParams(ctx)
+
+	// a fifth of 1000stake a block over 100 blocks a day
+	res, err := k.SimulateBurn(ctx, &types.QuerySimulateBurnRequest{
+		AvgFeePerBlock: coins("1000stake"),
+		BurnRate:       sdk.NewDecWithPrec(2, 1),
+		BlocksPerDay:   100,
+	})
+	require.NoError(t, err)
+	require.Equal(t, coins("200stake"), res.BurnPerBlock)
+	require.Equal(t, coins("20000stake"), res.BurnPerDay)
+	require.Equal(t, before, k.GetParams(ctx))
+
+	_, err = k.SimulateBurn(ctx, &types.QuerySimulateBurnRequest{AvgFeePerBlock: coins("1000stake"), BurnRate: sdk.NewDec(2)})
+	require.Error(t, err)
+}
+
+func TestBurnStatusReflectsBurnToggle(t *testing.T) {
+	feeApp, ctx, k := setupKeeper(t)
+	fundCollector(t, feeApp, ctx, coins("1000stake"))
+
+	setParams(ctx, k, func(p *types.FeeParams) { p.BurnRate = sdk.ZeroDec() })
+	status := k.BurnStatus(ctx)
+	require.False(t, status.Enabled)
+	require.Equal(t, sdk.ZeroDec(), status.Rate)
+	require.True(t, status.PendingBurn.Empty())
+
+	setParams(ctx, k, func(p *types.FeeParams) { p.BurnRate = sdk.NewDecWithPrec(5, 1) })
+	status = k.BurnStatus(ctx)
+	require.True(t, status.Enabled)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), status.Rate)
+	require.Equal(t, feeApp.AccountKeeper.GetModuleAddress(types.ModuleName).String(), status.Destination)
+	require.Equal(t, coins("500stake"), status.PendingBurn)
+
+	// routing a denom to burn enables burning at a zero rate
+	setParams(ctx, k, func(p *types.FeeParams) {
+		p.BurnRate = sdk.ZeroDec()
+		p.DestinationPolicies = []types.DestinationPolicy{{Denom: "atom", Destination: types.DestinationBurn}}
+	})
+	require.True(t, k.BurnStatus(ctx).Enabled)
+}
+
+func TestEffectiveBurnMixedDenomsWithExemptDenom(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) {
+		p.BurnRate = sdk.NewDecWithPrec(5, 1)
+		p.DestinationPolicies = []types.DestinationPolicy{
+			{Denom: "atom", Destination: types.DestinationCommunityPool},
+			{Denom: "osmo", Destination: types.DestinationBurn},
+		}
+	})
+
+	// atom goes to the community pool and is never burned, osmo is burned
+	// whole and half of the stake reaching the collector is burned
+	res, err := k.EffectiveBurn(ctx, &types.QueryEffectiveBurnRequest{Fee: coins("400atom,300osmo,1000stake")})
+	require.NoError(t, err)
+	require.Equal(t, coins("300osmo,500stake"), res.Burned)
+	require.Equal(t, []types.DenomBurn{
+		{Denom: "atom", Fee: sdk.NewInt(400), Burned: sdk.ZeroInt()},
+		{Denom: "osmo", Fee: sdk.NewInt(300), Burned: sdk.NewInt(300)},
+		{Denom: "stake", Fee: sdk.NewInt(1000), Burned: sdk.NewInt(500)},
+	}, res.Denoms)
+}
+
+func TestDeflationRateAnnualizesCappedWindow(t *testing.T) {
+	feeApp, ctx, k := setupKeeper(t)
+	ctx = ctx.WithBlockHeight(20000)
+	require.NoError(t, app.FundAccount(feeApp, ctx, newTestAddr(), coins("365000burntoken")))
+
+	// the window is capped at the last MaxQueryHeightRange blocks, leaving
+	// out the burn at height 10000
+	k.SetBlockFeeStats(ctx, types.BlockFeeStats{Height: 10000, Burned: coins("1000000burntoken")})
+	k.SetBlockFeeStats(ctx, types.BlockFeeStats{Height: 10001, Burned: coins("5000burntoken")})
+	k.SetBlockFeeStats(ctx, types.BlockFeeStats{Height: 20000, Burned: coins("5000burntoken")})
+
+	for _, blocks := range []uint64{0, 50000} {
+		res, err := k.DeflationRate(ctx, &types.QueryDeflationRateRequest{Denom: "burntoken", Blocks: blocks, BlocksPerDay: 100})
+		require.NoError(t, err)
+		require.Equal(t, uint64(types.MaxQueryHeightRange), res.Blocks)
+		require.Equal(t, sdk.NewInt(10000), res.Burned)
+		require.Equal(t, sdk.NewInt(365000), res.Supply)
+		// 10000 burned over 100 days of blocks is 36500 a year, 10% of supply
+		require.Equal(t, sdk.NewDec(10), res.AnnualizedPercent)
+	}
+}
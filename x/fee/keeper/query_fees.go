@@ -0,0 +1,185 @@
+package keeper
+
+import (
+	"math"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/marbar3778/fee/x/fee/types"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// SuggestFeeBump returns a fee FeeBumpRate above the larger of the current
+// required fee and the fee the stuck tx already pays.
+func (k Keeper) SuggestFeeBump(ctx sdk.Context, req *types.QuerySuggestFeeBumpRequest) (*types.QuerySuggestFeeBumpResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+
+	requiredFees := k.RequiredFees(ctx, req.Gas)
+
+	return &types.QuerySuggestFeeBumpResponse{
+		RequiredFee:  requiredFees,
+		SuggestedFee: types.SuggestFeeBump(requiredFees, req.CurrentFee, k.GetParams(ctx).FeeBumpRate),
+	}, nil
+}
+
+func querySuggestFeeBump(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QuerySuggestFeeBumpRequest
+	if err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, err := k.SuggestFeeBump(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
+
+func queryNetworkMinGasPrice(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryNetworkMinGasPriceRequest
+	if err := unmarshalOptionalRequest(legacyQuerierCdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	res := types.QueryNetworkMinGasPriceResponse{MinGasPrices: types.TruncateDecCoins(k.NetworkMinGasPrice(ctx), params.Precision)}
+	return marshalResponse(legacyQuerierCdc, res)
+}
+
+// EstimateFees returns the fee required for the given gas, together with the
+// part of it that will be burned and the net amount the chain collects.
+func (k Keeper) EstimateFees(ctx sdk.Context, req *types.QueryEstimateFeesRequest) (*types.QueryEstimateFeesResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+
+	gross := k.RequiredFees(ctx, req.Gas)
+	burned := k.EstimateBurn(ctx, gross)
+
+	return &types.QueryEstimateFeesResponse{
+		GrossFee: gross,
+		Burned:   burned,
+		NetFee:   gross.Sub(burned),
+	}, nil
+}
+
+func queryEstimateFees(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryEstimateFeesRequest
+	if err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, err := k.EstimateFees(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
+
+// MaxGasForFee returns the most gas a tx paying the given fee can declare,
+// floor(fee / min gas price) in the requested denom. Surcharges are not
+// accounted for, so a tx with msg, byte or signature fees affords less.
+func (k Keeper) MaxGasForFee(ctx sdk.Context, req *types.QueryMaxGasForFeeRequest) (*types.QueryMaxGasForFeeResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+	if err := sdk.ValidateDenom(req.Denom); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	price := k.EffectiveMinGasPrices(ctx).AmountOf(req.Denom)
+	if !price.IsPositive() {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "no min gas price in %s, any gas is affordable", req.Denom)
+	}
+
+	// truncating keeps ceil(price * gas), the fee required for gas, within fee
+	gas := req.Fee.AmountOf(req.Denom).ToDec().QuoTruncate(price).TruncateInt()
+	if !gas.IsUint64() {
+		return &types.QueryMaxGasForFeeResponse{Gas: math.MaxUint64}, nil
+	}
+
+	return &types.QueryMaxGasForFeeResponse{Gas: gas.Uint64()}, nil
+}
+
+func queryMaxGasForFee(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryMaxGasForFeeRequest
+	if err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, err := k.MaxGasForFee(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
+
+// ComputePriority returns the priority FeeParamDecorator assigns a tx paying
+// the given fee for the given gas, checked against MinMempoolPriority: the fee
+// is normalized to base denoms and priced with TxPriority.
+func (k Keeper) ComputePriority(ctx sdk.Context, req *types.QueryComputePriorityRequest) (*types.QueryComputePriorityResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+	if err := req.Fee.Validate(); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	return &types.QueryComputePriorityResponse{Priority: types.TxPriority(k.NormalizeFee(ctx, req.Fee), req.Gas, k.GetMinGasPrices(ctx))}, nil
+}
+
+func queryComputePriority(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryComputePriorityRequest
+	if err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, err := k.ComputePriority(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
+
+// RequiredFeeBreakdown returns the fee required from a tx of the given shape,
+// per component, as enforced by the ante handler.
+func (k Keeper) RequiredFeeBreakdown(ctx sdk.Context, req *types.QueryRequiredFeeBreakdownRequest) (*types.QueryRequiredFeeBreakdownResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+	if req.Msgs > math.MaxInt32 || req.Bytes > math.MaxInt32 || req.Sigs > math.MaxInt32 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "msgs, bytes and sigs must fit in an int32")
+	}
+
+	breakdown := types.ComputeRequiredFeeBreakdown(
+		k.GetParams(ctx),
+		k.EffectiveMinGasPrices(ctx),
+		req.Gas,
+		int(req.Msgs),
+		int(req.Bytes),
+		int(req.Sigs),
+	)
+
+	return &types.QueryRequiredFeeBreakdownResponse{Breakdown: breakdown}, nil
+}
+
+func queryRequiredFeeBreakdown(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryRequiredFeeBreakdownRequest
+	if err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, err := k.RequiredFeeBreakdown(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
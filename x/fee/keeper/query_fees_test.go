@@ -0,0 +1,72 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestSuggestFeeBump(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+
+	// the default bump rate is 10% over the 5stake min gas price
+	res, err := k.SuggestFeeBump(ctx, &types.QuerySuggestFeeBumpRequest{Gas: 1000})
+	require.NoError(t, err)
+	require.Equal(t, coins("5000stake"), res.RequiredFee)
+	require.Equal(t, coins("5500stake"), res.SuggestedFee)
+
+	// a stuck tx already paying more is bumped from its own fee
+	res, err = k.SuggestFeeBump(ctx, &types.QuerySuggestFeeBumpRequest{CurrentFee: coins("6000stake"), Gas: 1000})
+	require.NoError(t, err)
+	require.Equal(t, coins("6600stake"), res.SuggestedFee)
+}
+
+func TestEstimateFeesNetOfBurn(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) { p.BurnRate = sdk.NewDecWithPrec(2, 1) })
+
+	res, err := k.EstimateFees(ctx, &types.QueryEstimateFeesRequest{Gas: 1000})
+	require.NoError(t, err)
+	require.Equal(t, coins("5000stake"), res.GrossFee)
+	require.Equal(t, coins("1000stake"), res.Burned)
+	require.Equal(t, coins("4000stake"), res.NetFee)
+}
+
+func TestRequiredFeeBreakdown(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) {
+		p.MsgFee = coins("100stake")
+		p.ByteFee = decCoins("0.5stake")
+		p.SignatureFee = coins("300stake")
+	})
+
+	res, err := k.RequiredFeeBreakdown(ctx, &types.QueryRequiredFeeBreakdownRequest{Gas: 1000, Msgs: 3, Bytes: 401, Sigs: 2})
+	require.NoError(t, err)
+	require.Equal(t, types.RequiredFeeBreakdown{
+		Gas:        coins("5000stake"),
+		Msgs:       coins("300stake"),
+		Bytes:      coins("201stake"),
+		Signatures: coins("600stake"),
+		Dynamic:    sdk.NewCoins(),
+		Total:      coins("6101stake"),
+	}, res.Breakdown)
+}
+
+func TestMaxGasForFeeStaysWithinFee(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) { p.Fee = decCoins("0.3stake") })
+
+	for _, amount := range []int64{1, 10, 99, 1000003} {
+		fee := sdk.NewCoins(sdk.NewInt64Coin("stake", amount))
+		res, err := k.MaxGasForFee(ctx, &types.QueryMaxGasForFeeRequest{Fee: fee, Denom: "stake"})
+		require.NoError(t, err)
+
+		price := sdk.NewDecWithPrec(3, 1)
+		require.True(t, price.MulInt64(int64(res.Gas)).Ceil().TruncateInt().LTE(fee.AmountOf("stake")), amount)
+		// one more unit of gas would need more than the fee
+		require.True(t, price.MulInt64(int64(res.Gas+1)).GT(fee.AmountOf("stake").ToDec()), amount)
+	}
+}
@@ -0,0 +1,170 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/marbar3778/fee/x/fee/types"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// EffectiveConfig returns the fee configuration as currently applied, resolved
+// from the params, the dynamic base fee and the node's local config.
+func (k Keeper) EffectiveConfig(ctx sdk.Context) *types.QueryEffectiveConfigResponse {
+	params := k.GetParams(ctx)
+
+	baseFee := sdk.NewDecCoins()
+	if params.BaseFeeEnabled {
+		baseFee = k.GetBaseFee(ctx)
+	}
+
+	return &types.QueryEffectiveConfigResponse{
+		MinGasPrices:      k.EffectiveMinGasPrices(ctx),
+		ParamMinGasPrices: params.MinGasPrices(),
+		LocalMinGasPrices: ctx.MinGasPrices(),
+		BaseFee:           baseFee,
+		BurnRate:          params.BurnRate,
+		FeeMode:           params.FeeMode,
+		OffPeak:           params.IsOffPeak(k.GetLastBlockGas(ctx)),
+	}
+}
+
+func queryEffectiveConfig(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryEffectiveConfigRequest
+	if err := unmarshalOptionalRequest(legacyQuerierCdc, req.Data, &params); err != nil {
+		return nil, err
+	}
+
+	res := k.EffectiveConfig(ctx)
+	res.MinGasPrices = types.TruncateDecCoins(res.MinGasPrices, params.Precision)
+	res.ParamMinGasPrices = types.TruncateDecCoins(res.ParamMinGasPrices, params.Precision)
+	res.LocalMinGasPrices = types.TruncateDecCoins(res.LocalMinGasPrices, params.Precision)
+	res.BaseFee = types.TruncateDecCoins(res.BaseFee, params.Precision)
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
+
+// ValidateParams checks a params change without applying it. On top of
+// ValidateFee it checks the params against the chain they would be applied
+// to; an invalid payload is reported in the response rather than as an error.
+func (k Keeper) ValidateParams(ctx sdk.Context, req *types.QueryValidateParamsRequest) (*types.QueryValidateParamsResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+
+	if err := k.CheckParams(req.Params); err != nil {
+		return &types.QueryValidateParamsResponse{Valid: false, Error: err.Error()}, nil
+	}
+
+	return &types.QueryValidateParamsResponse{Valid: true}, nil
+}
+
+// CheckParams validates params as a whole against the chain they would be
+// applied to: ValidateFee plus the constraints on the keeper's wiring and
+// across fields.
+func (k Keeper) CheckParams(params types.FeeParams) error {
+	if err := types.ValidateFee(params); err != nil {
+		return err
+	}
+
+	if params.FeeMode == types.FeeModeValue && k.extensions.oracle == nil {
+		return fmt.Errorf("%s fee mode requires an oracle, but none is set", types.FeeModeValue)
+	}
+
+	// with a whitelist, txs can only meet the min gas prices in an allowed denom
+	if len(params.AllowedDenoms) > 0 {
+		payable := false
+		minGasPrices := params.MinGasPrices()
+		for _, price := range minGasPrices {
+			if params.IsDenomAllowed(price.Denom) {
+				payable = true
+				break
+			}
+		}
+		if !payable {
+			return fmt.Errorf("none of the min gas price denoms %s is an allowed denom", minGasPrices)
+		}
+	}
+
+	return nil
+}
+
+func queryValidateParams(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryValidateParamsRequest
+	if err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, err := k.ValidateParams(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
+
+// Features reports which fee features are active under the current params.
+func (k Keeper) Features(ctx sdk.Context) *types.QueryFeaturesResponse {
+	params := k.GetParams(ctx)
+
+	feeMode := params.FeeMode
+	if feeMode == "" {
+		feeMode = types.FeeModeGas
+	}
+
+	dustHandling := params.DustHandling
+	if dustHandling == "" {
+		dustHandling = types.DustToCollector
+	}
+
+	return &types.QueryFeaturesResponse{
+		FeeMode:              feeMode,
+		Burn:                 !params.BurnRate.IsNil() && params.BurnRate.IsPositive(),
+		BurnConfirmations:    params.BurnConfirmations > 0,
+		FeeSplits:            len(params.FeeSplits) > 0,
+		DustHandling:         dustHandling,
+		DynamicBaseFee:       params.BaseFeeEnabled,
+		OffPeakDiscount:      !params.OffPeakDiscount.IsNil() && params.OffPeakDiscount.IsPositive(),
+		DenomWhitelist:       len(params.AllowedDenoms) > 0,
+		DestinationRouting:   len(params.DestinationPolicies) > 0,
+		FallbackDenom:        params.FallbackDenom != "",
+		Surcharges:           !params.MsgFee.Empty() || !params.ByteFee.Empty() || !params.SignatureFee.Empty(),
+		StateRent:            params.StateRentRate > 0,
+		MinGasPerByte:        params.MinGasPerByte > 0,
+		SponsorSpendCaps:     len(params.SponsorSpendCaps) > 0,
+		FeeAllocationRecords: params.RecordFeeAllocations,
+		ParamChangeRateLimit: params.MinBlocksBetweenParamChanges > 0,
+		Oracle:               k.extensions.oracle != nil,
+		RefundUnusedGas:      params.RefundUnusedGas,
+	}
+}
+
+func queryFeatures(ctx sdk.Context, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	return marshalResponse(legacyQuerierCdc, k.Features(ctx))
+}
+
+func queryParamsProvenance(ctx sdk.Context, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	provenance, found := k.CurrentParamsProvenance(ctx)
+	if !found {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, "fee params unchanged since genesis")
+	}
+
+	return marshalResponse(legacyQuerierCdc, types.QueryParamsProvenanceResponse{Provenance: provenance})
+}
+
+func queryAuthority(k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	return marshalResponse(legacyQuerierCdc, types.QueryAuthorityResponse{Authority: k.GetAuthority()})
+}
+
+func queryParams(ctx sdk.Context, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	params := k.GetParams(ctx)
+	res := types.QueryParamsResponse{
+		Params:          params,
+		BurnRatePercent: types.FormatPercent(params.BurnRate),
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
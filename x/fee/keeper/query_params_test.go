@@ -0,0 +1,92 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/marbar3778/fee/x/fee/keeper"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestEffectiveConfigResolvesBaseFee(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) {
+		p.BaseFeeEnabled = true
+		p.TargetBlockGas = 1000000
+	})
+	k.SetBaseFee(ctx, decCoins("8stake"))
+
+	res := k.EffectiveConfig(ctx)
+	require.Equal(t, decCoins("5stake"), res.ParamMinGasPrices)
+	require.Equal(t, decCoins("8stake"), res.BaseFee)
+	require.Equal(t, decCoins("8stake"), res.MinGasPrices)
+}
+
+func TestValidateParams(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+
+	invalid := types.DefaultParams()
+	invalid.BurnRate = sdk.NewDec(2)
+	res, err := k.ValidateParams(ctx, &types.QueryValidateParamsRequest{Params: invalid})
+	require.NoError(t, err)
+	require.False(t, res.Valid)
+	require.Equal(t, "burn rate must be between 0 and 1: 2.000000000000000000", res.Error)
+
+	// cross-field: no min gas price can be paid in an allowed denom
+	invalid = types.DefaultParams()
+	invalid.AllowedDenoms = []string{"atom"}
+	res, err = k.ValidateParams(ctx, &types.QueryValidateParamsRequest{Params: invalid})
+	require.NoError(t, err)
+	require.False(t, res.Valid)
+	require.Contains(t, res.Error, "is an allowed denom")
+
+	res, err = k.ValidateParams(ctx, &types.QueryValidateParamsRequest{Params: types.DefaultParams()})
+	require.NoError(t, err)
+	require.Equal(t, &types.QueryValidateParamsResponse{Valid: true}, res)
+
+	// nothing is applied
+	require.Equal(t, types.DefaultParams(), k.GetParams(ctx))
+}
+
+func TestFeaturesReflectParams(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+
+	res := k.Features(ctx)
+	require.False(t, res.Burn)
+	require.False(t, res.DynamicBaseFee)
+	require.False(t, res.Surcharges)
+	require.Equal(t, types.FeeModeGas, res.FeeMode)
+	require.Equal(t, types.DustToCollector, res.DustHandling)
+
+	setParams(ctx, k, func(p *types.FeeParams) {
+		p.BurnRate = sdk.NewDecWithPrec(1, 1)
+		p.BaseFeeEnabled = true
+		p.TargetBlockGas = 1000000
+		p.MsgFee = coins("10stake")
+		p.DustHandling = types.DustBurn
+	})
+
+	res = k.Features(ctx)
+	require.True(t, res.Burn)
+	require.True(t, res.DynamicBaseFee)
+	require.True(t, res.Surcharges)
+	require.Equal(t, types.DustBurn, res.DustHandling)
+	require.False(t, res.FeeSplits)
+}
+
+func TestQueryAuthorityDefaultsToGov(t *testing.T) {
+	feeApp, ctx, k := setupKeeper(t)
+	querier := keeper.NewQuerier(k, feeApp.LegacyAmino())
+
+	bz, err := querier(ctx, []string{types.QueryAuthority}, abci.RequestQuery{})
+	require.NoError(t, err)
+
+	var res types.QueryAuthorityResponse
+	require.NoError(t, feeApp.LegacyAmino().UnmarshalJSON(bz, &res))
+	require.Equal(t, authtypes.NewModuleAddress(govtypes.ModuleName).String(), res.Authority)
+}
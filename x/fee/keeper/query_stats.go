@@ -0,0 +1,136 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/marbar3778/fee/x/fee/types"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// GasPriceHistory returns the average effective gas price paid in the
+// requested denom for every block in the range that collected fees in it,
+// truncated to the requested precision.
+func (k Keeper) GasPriceHistory(ctx sdk.Context, req *types.QueryGasPriceHistoryRequest) (*types.QueryGasPriceHistoryResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+	if err := validateHeightRange(req.FromHeight, req.ToHeight); err != nil {
+		return nil, err
+	}
+	if err := sdk.ValidateDenom(req.Denom); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	prices := []types.GasPricePoint{}
+	k.IterateBlockFeeStats(ctx, req.FromHeight, req.ToHeight, func(stats types.BlockFeeStats) bool {
+		if d, ok := stats.Denom(req.Denom); ok {
+			prices = append(prices, types.GasPricePoint{Height: stats.Height, GasPrice: types.TruncateDec(d.AverageGasPrice(), req.Precision)})
+		}
+		return false
+	})
+
+	return &types.QueryGasPriceHistoryResponse{Prices: prices}, nil
+}
+
+func queryGasPriceHistory(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryGasPriceHistoryRequest
+	if err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, err := k.GasPriceHistory(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
+
+// TxCount returns the number of txs that paid a non-zero fee in the blocks of
+// the range.
+func (k Keeper) TxCount(ctx sdk.Context, req *types.QueryTxCountRequest) (*types.QueryTxCountResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+	if err := validateHeightRange(req.FromHeight, req.ToHeight); err != nil {
+		return nil, err
+	}
+
+	var count uint64
+	k.IterateBlockFeeStats(ctx, req.FromHeight, req.ToHeight, func(stats types.BlockFeeStats) bool {
+		count += stats.TxCount
+		return false
+	})
+
+	return &types.QueryTxCountResponse{Count: count}, nil
+}
+
+func queryTxCount(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryTxCountRequest
+	if err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, err := k.TxCount(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
+
+// FeeStatsByDenom sums, per denom, the fees collected and burned by the blocks
+// in the range, as recorded in their block fee stats.
+func (k Keeper) FeeStatsByDenom(ctx sdk.Context, req *types.QueryFeeStatsByDenomRequest) (*types.QueryFeeStatsByDenomResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+	if err := validateHeightRange(req.FromHeight, req.ToHeight); err != nil {
+		return nil, err
+	}
+
+	collected, burned := sdk.NewCoins(), sdk.NewCoins()
+	k.IterateBlockFeeStats(ctx, req.FromHeight, req.ToHeight, func(stats types.BlockFeeStats) bool {
+		collected = collected.Add(stats.Collected...)
+		burned = burned.Add(stats.Burned...)
+		return false
+	})
+
+	res := &types.QueryFeeStatsByDenomResponse{Denoms: []types.DenomFeeTotals{}}
+	for _, coin := range collected.Add(burned...) {
+		res.Denoms = append(res.Denoms, types.DenomFeeTotals{
+			Denom:     coin.Denom,
+			Collected: collected.AmountOf(coin.Denom),
+			Burned:    burned.AmountOf(coin.Denom),
+		})
+	}
+
+	return res, nil
+}
+
+func queryFeeStatsByDenom(ctx sdk.Context, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryFeeStatsByDenomRequest
+	if err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, err := k.FeeStatsByDenom(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalResponse(legacyQuerierCdc, res)
+}
+
+func validateHeightRange(fromHeight, toHeight int64) error {
+	if fromHeight < 0 || toHeight < fromHeight {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid height range: %d to %d", fromHeight, toHeight)
+	}
+	if toHeight-fromHeight >= types.MaxQueryHeightRange {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "height range %d to %d exceeds %d blocks", fromHeight, toHeight, types.MaxQueryHeightRange)
+	}
+
+	return nil
+}
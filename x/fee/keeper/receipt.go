@@ -0,0 +1,30 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// RecordFeeReceipt stores the receipt for the fee allocation of the tx being
+// delivered in the transient store, if EmitFeeReceipt is enabled.
+func (k Keeper) RecordFeeReceipt(ctx sdk.Context, allocation types.FeeAllocation) {
+	if !k.GetParams(ctx).EmitFeeReceipt {
+		return
+	}
+
+	receipt := types.NewFeeReceipt(allocation)
+	ctx.TransientStore(k.tStoreKey).Set(types.KeyPrefix(types.FeeReceiptKey), k.cdc.MustMarshalBinaryBare(&receipt))
+}
+
+// TakeFeeReceipt returns the recorded fee receipt, if any, and deletes it.
+func (k Keeper) TakeFeeReceipt(ctx sdk.Context) (receipt types.FeeReceipt, found bool) {
+	store := ctx.TransientStore(k.tStoreKey)
+	bz := store.Get(types.KeyPrefix(types.FeeReceiptKey))
+	if bz == nil {
+		return receipt, false
+	}
+	store.Delete(types.KeyPrefix(types.FeeReceiptKey))
+
+	k.cdc.MustUnmarshalBinaryBare(bz, &receipt)
+	return receipt, true
+}
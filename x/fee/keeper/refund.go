@@ -0,0 +1,123 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// RecordGasCharge stores the part of the fee that reached the fee collector
+// for the tx being delivered in the transient store, if RefundUnusedGas or
+// the gas over declaration penalty is enabled. The ante handler writes
+// persist when the msgs fail, so failed txs are settled too.
+func (k Keeper) RecordGasCharge(ctx sdk.Context, payer sdk.AccAddress, collected sdk.Coins, gasWanted uint64) {
+	if params := k.GetParams(ctx); !params.RefundUnusedGas && !params.PenalizesGasOverDeclaration() {
+		return
+	}
+
+	charge := types.GasCharge{Payer: payer.String(), Collected: collected, GasWanted: gasWanted}
+	ctx.TransientStore(k.tStoreKey).Set(types.KeyPrefix(types.GasChargeKey), types.ModuleCdc.LegacyAmino.MustMarshalBinaryBare(charge))
+}
+
+// SettleGasCharge settles the recorded gas charge of the delivered tx, if
+// any, and deletes it. The refund of the gas the tx did not use is queued
+// when RefundUnusedGas is enabled. A tx that over declared its gas is charged
+// the GasOverDeclarationPenalty share of its collected fee out of that fee:
+// it is withheld from the refund, and never charged on top of the fee.
+func (k Keeper) SettleGasCharge(ctx sdk.Context, gasUsed uint64) {
+	store := ctx.TransientStore(k.tStoreKey)
+	bz := store.Get(types.KeyPrefix(types.GasChargeKey))
+	if bz == nil {
+		return
+	}
+	store.Delete(types.KeyPrefix(types.GasChargeKey))
+
+	var charge types.GasCharge
+	types.ModuleCdc.LegacyAmino.MustUnmarshalBinaryBare(bz, &charge)
+
+	params := k.GetParams(ctx)
+	refund := sdk.NewCoins()
+	if params.RefundUnusedGas {
+		refund = types.UnusedGasRefund(charge.Collected, charge.GasWanted, gasUsed)
+	}
+
+	if params.IsGasOverDeclared(charge.GasWanted, gasUsed) {
+		refund = chargeGasPenalty(ctx, params, charge, refund)
+	}
+	if refund.Empty() {
+		return
+	}
+
+	var count uint64
+	if bz := store.Get(types.KeyPrefix(types.GasRefundCountKey)); bz != nil {
+		count = sdk.BigEndianToUint64(bz)
+	}
+	refunds := prefix.NewStore(store, types.KeyPrefix(types.GasRefundKey))
+	refunds.Set(sdk.Uint64ToBigEndian(count), types.ModuleCdc.LegacyAmino.MustMarshalBinaryBare(types.GasRefund{Payer: charge.Payer, Amount: refund}))
+	store.Set(types.KeyPrefix(types.GasRefundCountKey), sdk.Uint64ToBigEndian(count+1))
+}
+
+// chargeGasPenalty withholds the gas over declaration penalty of charge from
+// refund and returns what is left of the refund. The part of the penalty the
+// refund does not cover is already paid by the collected fee the payer does
+// not get back.
+func chargeGasPenalty(ctx sdk.Context, params types.FeeParams, charge types.GasCharge, refund sdk.Coins) sdk.Coins {
+	penalty := params.GasOverDeclarationPenaltyOf(charge.Collected)
+	if penalty.Empty() {
+		return refund
+	}
+
+	withheld := capCoins(penalty, refund)
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			params.EventType(types.EventTypeGasPenalty),
+			sdk.NewAttribute(types.AttributeKeyPayer, charge.Payer),
+			sdk.NewAttribute(types.AttributeKeyAmount, penalty.String()),
+			sdk.NewAttribute(types.AttributeKeyWithheld, withheld.String()),
+		),
+	)
+
+	return refund.Sub(withheld)
+}
+
+// RefundUnusedGas pays the unused gas refunds queued in the block out of the
+// fee collector in delivery order, each capped at what the collector still
+// holds. It must run before the collector is burned.
+func (k Keeper) RefundUnusedGas(ctx sdk.Context) error {
+	var queued []types.GasRefund
+	iterator := prefix.NewStore(ctx.TransientStore(k.tStoreKey), types.KeyPrefix(types.GasRefundKey)).Iterator(nil, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		var refund types.GasRefund
+		types.ModuleCdc.LegacyAmino.MustUnmarshalBinaryBare(iterator.Value(), &refund)
+		queued = append(queued, refund)
+	}
+	iterator.Close()
+
+	params := k.GetParams(ctx)
+	collector := k.accountKeeper.GetModuleAddress(authtypes.FeeCollectorName)
+	for _, refund := range queued {
+		amount := capCoins(refund.Amount, k.bankKeeper.GetAllBalances(ctx, collector))
+		if amount.Empty() {
+			continue
+		}
+
+		payer, err := sdk.AccAddressFromBech32(refund.Payer)
+		if err != nil {
+			return err
+		}
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, authtypes.FeeCollectorName, payer, amount); err != nil {
+			return err
+		}
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				params.EventType(types.EventTypeGasRefund),
+				sdk.NewAttribute(types.AttributeKeyPayer, refund.Payer),
+				sdk.NewAttribute(types.AttributeKeyRefund, amount.String()),
+			),
+		)
+	}
+
+	return nil
+}
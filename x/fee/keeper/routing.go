@@ -0,0 +1,94 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// RouteFees moves the fees paid in denoms with a burn or community pool
+// destination policy from the fee collector to that destination. It returns
+// where the routed coins actually went, with the coins KeepMinSupply kept from
+// being burned as collected, and the remainder that was not routed. The fee
+// must already have been deducted to the collector.
+func (k Keeper) RouteFees(ctx sdk.Context, fee sdk.Coins) (types.FeeAllocation, sdk.Coins, error) {
+	routed := types.FeeAllocation{Collected: sdk.NewCoins(), Burned: sdk.NewCoins(), CommunityPool: sdk.NewCoins()}
+	params := k.GetParams(ctx)
+	if len(params.DestinationPolicies) == 0 {
+		return routed, fee, nil
+	}
+
+	burn, communityPool, remainder := sdk.NewCoins(), sdk.NewCoins(), sdk.NewCoins()
+	for _, coin := range fee {
+		switch params.Destination(coin.Denom) {
+		case types.DestinationBurn:
+			burn = burn.Add(coin)
+		case types.DestinationCommunityPool:
+			communityPool = communityPool.Add(coin)
+		default:
+			remainder = remainder.Add(coin)
+		}
+	}
+
+	if !burn.Empty() {
+		burned, err := k.burnFromCollector(ctx, params, burn)
+		if err != nil {
+			return types.FeeAllocation{}, nil, err
+		}
+		routed.Burned = routed.Burned.Add(burned...)
+		routed.Collected = routed.Collected.Add(burn.Sub(burned)...)
+	}
+
+	if !communityPool.Empty() {
+		funded, burned, err := k.fundCommunityPool(ctx, params, communityPool)
+		if err != nil {
+			return types.FeeAllocation{}, nil, err
+		}
+		routed.CommunityPool = funded
+		routed.Burned = routed.Burned.Add(burned...)
+		routed.Collected = routed.Collected.Add(communityPool.Sub(funded).Sub(burned)...)
+	}
+
+	return routed, remainder, nil
+}
+
+// fundCommunityPool funds the community pool from the fee collector. When that
+// fails, e.g. with no distribution module wired, the coins are burned instead
+// if BurnOnCommunityPoolFailure is set, and a fallback event is emitted.
+// Otherwise the error is returned, failing the tx; a failed tx keeps no
+// events, so the error names the failure. It returns the coins funded and
+// burned.
+func (k Keeper) fundCommunityPool(ctx sdk.Context, params types.FeeParams, coins sdk.Coins) (funded, burned sdk.Coins, err error) {
+	collector := k.accountKeeper.GetModuleAddress(authtypes.FeeCollectorName)
+
+	// a failed attempt may have moved part of the coins, so it runs on a
+	// cache that is only written on success
+	cacheCtx, write := ctx.CacheContext()
+	fundErr := k.distrKeeper.FundCommunityPool(cacheCtx, coins, collector)
+	if fundErr == nil {
+		write()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+		return coins, sdk.NewCoins(), nil
+	}
+
+	if !params.BurnOnCommunityPoolFailure {
+		return nil, nil, sdkerrors.Wrapf(fundErr, "failed to fund the community pool with %s", coins)
+	}
+
+	burned, err = k.burnFromCollector(ctx, params, coins)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			params.EventType(types.EventTypePoolFallback),
+			sdk.NewAttribute(types.AttributeKeyAmount, coins.String()),
+			sdk.NewAttribute(types.AttributeKeyBurned, burned.String()),
+			sdk.NewAttribute(types.AttributeKeyError, fundErr.Error()),
+		),
+	)
+
+	return sdk.NewCoins(), burned, nil
+}
@@ -0,0 +1,92 @@
+package keeper_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/marbar3778/fee/x/fee/keeper"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestRouteFeesByDenom(t *testing.T) {
+	feeApp, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) {
+		p.DestinationPolicies = []types.DestinationPolicy{
+			{Denom: "stake", Destination: types.DestinationBurn},
+			{Denom: "ibcatom", Destination: types.DestinationCommunityPool},
+		}
+	})
+
+	fee := coins("50ibcatom,100stake,7uosmo")
+	fundCollector(t, feeApp, ctx, fee)
+	supply := feeApp.BankKeeper.GetSupply(ctx).GetTotal()
+
+	routed, remainder, err := k.RouteFees(ctx, fee)
+	require.NoError(t, err)
+	require.Equal(t, coins("7uosmo"), remainder)
+	require.Equal(t, coins("100stake"), routed.Burned)
+	require.Equal(t, coins("50ibcatom"), routed.CommunityPool)
+	require.Equal(t, coins("7uosmo"), collectorBalance(feeApp, ctx))
+
+	require.Equal(t, supply.Sub(coins("100stake")), feeApp.BankKeeper.GetSupply(ctx).GetTotal())
+	require.Equal(t, sdk.NewDecCoinsFromCoins(coins("50ibcatom")...), feeApp.DistrKeeper.GetFeePoolCommunityCoins(ctx))
+}
+
+// failingDistrKeeper fails every community pool funding.
+type failingDistrKeeper struct{}
+
+func (failingDistrKeeper) FundCommunityPool(sdk.Context, sdk.Coins, sdk.AccAddress) error {
+	return errors.New("community pool unavailable")
+}
+
+func TestRouteFeesCommunityPoolFailure(t *testing.T) {
+	feeApp, ctx, _ := setupKeeper(t)
+	k := *keeper.NewKeeper(
+		feeApp.AppCodec(), feeApp.GetKey(types.StoreKey), feeApp.GetMemKey(types.MemStoreKey), feeApp.GetTKey(types.TStoreKey), feeApp.GetSubspace(types.ModuleName),
+		feeApp.AccountKeeper, feeApp.BankKeeper, feeApp.StakingKeeper, failingDistrKeeper{},
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+	)
+	params := setParams(ctx, k, func(p *types.FeeParams) {
+		p.DestinationPolicies = []types.DestinationPolicy{{Denom: "ibcatom", Destination: types.DestinationCommunityPool}}
+	})
+
+	fee := coins("50ibcatom")
+	fundCollector(t, feeApp, ctx, fee)
+
+	// without the fallback the routing fails and the fees stay collected
+	_, _, err := k.RouteFees(ctx, fee)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "community pool unavailable")
+	require.Equal(t, fee, collectorBalance(feeApp, ctx))
+
+	params.BurnOnCommunityPoolFailure = true
+	k.SetParams(ctx, params)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	supply := feeApp.BankKeeper.GetSupply(ctx).GetTotal()
+
+	routed, _, err := k.RouteFees(ctx, fee)
+	require.NoError(t, err)
+	require.Equal(t, fee, routed.Burned)
+	require.True(t, routed.CommunityPool.Empty())
+	require.True(t, collectorBalance(feeApp, ctx).Empty())
+	require.Equal(t, supply.Sub(fee), feeApp.BankKeeper.GetSupply(ctx).GetTotal())
+
+	var fallback sdk.Event
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == types.EventTypePoolFallback {
+			fallback = event
+		}
+	}
+	require.Equal(t, types.EventTypePoolFallback, fallback.Type)
+	attrs := make(map[string]string)
+	for _, attr := range fallback.Attributes {
+		attrs[string(attr.Key)] = string(attr.Value)
+	}
+	require.Equal(t, fee.String(), attrs[types.AttributeKeyBurned])
+	require.Equal(t, "community pool unavailable", attrs[types.AttributeKeyError])
+}
@@ -0,0 +1,50 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// PayFeeSplits pays every configured split its share of fee out of the fee
+// collector and handles the rounding dust per DustHandling. It returns where
+// fee actually went: the shares paid, the dust burned and what is left for the
+// collector.
+func (k Keeper) PayFeeSplits(ctx sdk.Context, fee sdk.Coins) (types.FeeAllocation, error) {
+	params := k.GetParams(ctx)
+	splits := params.FeeSplits
+	if len(splits) == 0 {
+		return types.FeeAllocation{Collected: fee, Burned: sdk.NewCoins(), CommunityPool: sdk.NewCoins()}, nil
+	}
+
+	allocation := types.FeeAllocation{Burned: sdk.NewCoins(), CommunityPool: sdk.NewCoins()}
+
+	shares, dust, remainder := types.AllocateFeeSplits(params, fee)
+	for i, split := range splits {
+		if shares[i].Empty() {
+			continue
+		}
+
+		recipient, err := sdk.AccAddressFromBech32(split.Address)
+		if err != nil {
+			return types.FeeAllocation{}, err
+		}
+
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, authtypes.FeeCollectorName, recipient, shares[i]); err != nil {
+			return types.FeeAllocation{}, err
+		}
+		allocation.Recipients = append(allocation.Recipients, types.FeeRecipient{Address: split.Address, Amount: shares[i]})
+	}
+
+	if !dust.Empty() {
+		burned, err := k.burnFromCollector(ctx, params, dust)
+		if err != nil {
+			return types.FeeAllocation{}, err
+		}
+		allocation.Burned = burned
+		remainder = remainder.Add(dust.Sub(burned)...)
+	}
+	allocation.Collected = remainder
+
+	return allocation, nil
+}
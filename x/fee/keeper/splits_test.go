@@ -0,0 +1,47 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestPayFeeSplitsDustHandling(t *testing.T) {
+	for _, tc := range []struct {
+		policy    string
+		first     string
+		burned    int64
+		remainder string
+	}{
+		// 30% of 15stake truncates to 4stake, leaving 1stake of dust
+		{types.DustToCollector, "4stake", 0, "7stake"},
+		{types.DustToFirstRecipient, "5stake", 0, "6stake"},
+		{types.DustBurn, "4stake", 1, "6stake"},
+	} {
+		t.Run(tc.policy, func(t *testing.T) {
+			feeApp, ctx, k := setupKeeper(t)
+			first, second := newTestAddr(), newTestAddr()
+			setParams(ctx, k, func(p *types.FeeParams) {
+				p.DustHandling = tc.policy
+				p.FeeSplits = []types.FeeSplit{
+					{Address: first.String(), Weight: sdk.NewDecWithPrec(3, 1)},
+					{Address: second.String(), Weight: sdk.NewDecWithPrec(3, 1)},
+				}
+			})
+			fundCollector(t, feeApp, ctx, coins("15stake"))
+			supply := feeApp.BankKeeper.GetSupply(ctx).GetTotal().AmountOf("stake")
+
+			allocation, err := k.PayFeeSplits(ctx, coins("15stake"))
+			require.NoError(t, err)
+			require.Equal(t, coins(tc.remainder), allocation.Collected)
+			require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", tc.burned)), allocation.Burned)
+			require.Equal(t, coins(tc.first), feeApp.BankKeeper.GetAllBalances(ctx, first))
+			require.Equal(t, coins("4stake"), feeApp.BankKeeper.GetAllBalances(ctx, second))
+			require.Equal(t, coins(tc.remainder), collectorBalance(feeApp, ctx))
+			require.Equal(t, supply.SubRaw(tc.burned), feeApp.BankKeeper.GetSupply(ctx).GetTotal().AmountOf("stake"))
+		})
+	}
+}
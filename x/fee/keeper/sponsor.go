@@ -0,0 +1,48 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// ChargeSponsorCap adds fee to what payer spent on fees in the current block
+// and rejects it if that exceeds the payer's configured per-block spend cap.
+// Only delivered txs count as spent: CheckTx checks the fee against the cap
+// without recording it, as mempool txs would otherwise use up the cap of the
+// block before any of them is delivered.
+// The SDK version in use has no fee grant module, so caps apply to the
+// account paying the fee, e.g. a sponsor set as the tx fee payer.
+func (k Keeper) ChargeSponsorCap(ctx sdk.Context, payer sdk.AccAddress, fee sdk.Coins) error {
+	spendCap, found := k.GetParams(ctx).SpendCap(payer)
+	if !found {
+		return nil
+	}
+
+	spent := k.GetSponsorSpent(ctx, payer).Add(fee...)
+	if !spendCap.IsAllGTE(spent) {
+		return sdkerrors.Wrapf(types.ErrSponsorCapExceeded, "sponsor %s would spend %s this block, cap is %s", payer, spent, spendCap)
+	}
+
+	if ctx.IsCheckTx() {
+		return nil
+	}
+
+	store := prefix.NewStore(ctx.TransientStore(k.tStoreKey), types.KeyPrefix(types.SponsorSpentKey))
+	store.Set(payer, types.ModuleCdc.LegacyAmino.MustMarshalBinaryBare(spent))
+	return nil
+}
+
+// GetSponsorSpent returns the fees addr paid so far in the current block.
+func (k Keeper) GetSponsorSpent(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	store := prefix.NewStore(ctx.TransientStore(k.tStoreKey), types.KeyPrefix(types.SponsorSpentKey))
+	bz := store.Get(addr)
+	if bz == nil {
+		return sdk.NewCoins()
+	}
+
+	var spent sdk.Coins
+	types.ModuleCdc.LegacyAmino.MustUnmarshalBinaryBare(bz, &spent)
+	return spent
+}
@@ -0,0 +1,103 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// RecordFees adds the fees paid by a tx with the given gas limit to the
+// current block's fee stats.
+func (k Keeper) RecordFees(ctx sdk.Context, fees sdk.Coins, gas uint64) {
+	stats := k.currentBlockFeeStats(ctx)
+	stats.AddFee(fees, gas)
+	k.SetBlockFeeStats(ctx, stats)
+}
+
+// RecordBurn adds burned fees to the current block's fee stats.
+func (k Keeper) RecordBurn(ctx sdk.Context, burned sdk.Coins) {
+	stats := k.currentBlockFeeStats(ctx)
+	stats.AddBurn(burned)
+	k.SetBlockFeeStats(ctx, stats)
+}
+
+// EmitBlockFeeSummary emits the block_fee_summary event with the fees the
+// current block collected and burned, as recorded in its fee stats.
+func (k Keeper) EmitBlockFeeSummary(ctx sdk.Context) {
+	stats := k.currentBlockFeeStats(ctx)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			k.GetParams(ctx).EventType(types.EventTypeBlockFeeSummary),
+			sdk.NewAttribute(types.AttributeKeyCollected, stats.Collected.String()),
+			sdk.NewAttribute(types.AttributeKeyBurned, stats.Burned.String()),
+			sdk.NewAttribute(types.AttributeKeyGas, fmt.Sprintf("%d", stats.GasWanted)),
+		),
+	)
+}
+
+func (k Keeper) currentBlockFeeStats(ctx sdk.Context) types.BlockFeeStats {
+	stats, found := k.GetBlockFeeStats(ctx, ctx.BlockHeight())
+	if !found {
+		stats = types.BlockFeeStats{Height: ctx.BlockHeight(), Collected: sdk.NewCoins(), Burned: sdk.NewCoins()}
+	}
+
+	return stats
+}
+
+// GetBlockFeeStats returns the fee stats recorded at the given height.
+func (k Keeper) GetBlockFeeStats(ctx sdk.Context, height int64) (stats types.BlockFeeStats, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.BlockFeeStatsKey))
+	bz := store.Get(sdk.Uint64ToBigEndian(uint64(height)))
+	if bz == nil {
+		return stats, false
+	}
+
+	types.ModuleCdc.LegacyAmino.MustUnmarshalBinaryBare(bz, &stats)
+	return stats, true
+}
+
+// SetBlockFeeStats stores the fee stats for their height.
+func (k Keeper) SetBlockFeeStats(ctx sdk.Context, stats types.BlockFeeStats) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.BlockFeeStatsKey))
+	store.Set(sdk.Uint64ToBigEndian(uint64(stats.Height)), types.ModuleCdc.LegacyAmino.MustMarshalBinaryBare(stats))
+}
+
+// IterateBlockFeeStats iterates over the fee stats recorded between the given
+// heights, inclusive, in ascending order until cb returns true.
+func (k Keeper) IterateBlockFeeStats(ctx sdk.Context, fromHeight, toHeight int64, cb func(stats types.BlockFeeStats) (stop bool)) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.BlockFeeStatsKey))
+	iterator := store.Iterator(sdk.Uint64ToBigEndian(uint64(fromHeight)), sdk.Uint64ToBigEndian(uint64(toHeight)+1))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var stats types.BlockFeeStats
+		types.ModuleCdc.LegacyAmino.MustUnmarshalBinaryBare(iterator.Value(), &stats)
+		if cb(stats) {
+			break
+		}
+	}
+}
+
+// PruneBlockFeeStats deletes the fee stats recorded more than
+// StatsRetentionBlocks blocks before the current height.
+func (k Keeper) PruneBlockFeeStats(ctx sdk.Context) {
+	retention := k.GetParams(ctx).StatsRetentionBlocks
+	if retention == 0 || ctx.BlockHeight() <= int64(retention) {
+		return
+	}
+
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.BlockFeeStatsKey))
+	iterator := store.Iterator(nil, sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight())-retention))
+	defer iterator.Close()
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
@@ -0,0 +1,73 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestGasPriceHistory(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+
+	k.RecordFees(ctx.WithBlockHeight(2), coins("1000stake"), 200)
+	k.RecordFees(ctx.WithBlockHeight(2), coins("500stake"), 300)
+	k.RecordFees(ctx.WithBlockHeight(3), coins("100atom"), 100)
+	k.RecordFees(ctx.WithBlockHeight(4), coins("700stake"), 100)
+
+	res, err := k.GasPriceHistory(ctx, &types.QueryGasPriceHistoryRequest{FromHeight: 1, ToHeight: 4, Denom: "stake"})
+	require.NoError(t, err)
+	require.Equal(t, []types.GasPricePoint{
+		{Height: 2, GasPrice: sdk.NewDec(3)},
+		{Height: 4, GasPrice: sdk.NewDec(7)},
+	}, res.Prices)
+
+	_, err = k.GasPriceHistory(ctx, &types.QueryGasPriceHistoryRequest{FromHeight: 1, ToHeight: 1 + types.MaxQueryHeightRange, Denom: "stake"})
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+}
+
+func TestPruneBlockFeeStats(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	setParams(ctx, k, func(p *types.FeeParams) { p.StatsRetentionBlocks = 2 })
+
+	for height := int64(1); height <= 5; height++ {
+		k.RecordFees(ctx.WithBlockHeight(height), coins("10stake"), 10)
+	}
+	k.PruneBlockFeeStats(ctx.WithBlockHeight(5))
+
+	for height := int64(1); height <= 5; height++ {
+		_, found := k.GetBlockFeeStats(ctx, height)
+		require.Equal(t, height >= 3, found, "height %d", height)
+	}
+}
+
+func TestGasPriceHistoryPrecision(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	k.RecordFees(ctx, coins("1000stake"), 3)
+
+	precision := uint32(4)
+	res, err := k.GasPriceHistory(ctx, &types.QueryGasPriceHistoryRequest{FromHeight: 1, ToHeight: 2, Denom: "stake", Precision: &precision})
+	require.NoError(t, err)
+	require.Equal(t, []types.GasPricePoint{{Height: 2, GasPrice: sdk.MustNewDecFromStr("333.3333")}}, res.Prices)
+}
+
+func TestFeeStatsByDenomAggregatesPerDenom(t *testing.T) {
+	_, ctx, k := setupKeeper(t)
+	ctx = ctx.WithBlockHeight(10)
+	k.SetBlockFeeStats(ctx, types.BlockFeeStats{Height: 4, Collected: coins("1000stake"), Burned: coins("1000stake")})
+	k.SetBlockFeeStats(ctx, types.BlockFeeStats{Height: 5, Collected: coins("10atom,100stake"), Burned: coins("50stake")})
+	k.SetBlockFeeStats(ctx, types.BlockFeeStats{Height: 6, Collected: coins("5atom,7uosmo"), Burned: coins("2atom")})
+	k.SetBlockFeeStats(ctx, types.BlockFeeStats{Height: 7, Collected: coins("200stake"), Burned: coins("3ibcburn,25stake")})
+
+	res, err := k.FeeStatsByDenom(ctx, &types.QueryFeeStatsByDenomRequest{FromHeight: 5, ToHeight: 7})
+	require.NoError(t, err)
+	require.Equal(t, []types.DenomFeeTotals{
+		{Denom: "atom", Collected: sdk.NewInt(15), Burned: sdk.NewInt(2)},
+		{Denom: "ibcburn", Collected: sdk.ZeroInt(), Burned: sdk.NewInt(3)},
+		{Denom: "stake", Collected: sdk.NewInt(300), Burned: sdk.NewInt(75)},
+		{Denom: "uosmo", Collected: sdk.NewInt(7), Burned: sdk.ZeroInt()},
+	}, res.Denoms)
+}
@@ -0,0 +1,84 @@
+package fee
+
+import (
+	"strconv"
+
+	"github.com/gogo/protobuf/proto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	paramproposal "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
+	"github.com/marbar3778/fee/x/fee/keeper"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+// NewParamChangeProposalHandler wraps the params module's proposal handler so
+// governance changes to the fee params are rate limited by
+// MinBlocksBetweenParamChanges, the changed params are validated as a whole
+// like UpdateParams validates them, denoms they remove from the whitelist get
+// their grace period and the proposal ID is recorded as the params
+// provenance. Proposals not touching the fee subspace are passed through
+// untouched.
+func NewParamChangeProposalHandler(k keeper.Keeper, gk types.GovKeeper, next govtypes.Handler) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		proposal, ok := content.(*paramproposal.ParameterChangeProposal)
+		if !ok || !changesFeeParams(proposal) {
+			return next(ctx, content)
+		}
+
+		if err := k.CheckParamChangeAllowed(ctx); err != nil {
+			return err
+		}
+
+		// the change is applied in a cache so the resulting params can be
+		// checked as a whole, as UpdateParams does, before they are written
+		old := k.GetParams(ctx)
+		cacheCtx, write := ctx.CacheContext()
+		if err := next(cacheCtx, content); err != nil {
+			return err
+		}
+		if err := k.CheckParams(k.GetParams(cacheCtx)); err != nil {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+		}
+		write()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+
+		k.SetLastParamChangeHeight(ctx, ctx.BlockHeight())
+		k.RecordDenomRemovals(ctx, old, k.GetParams(ctx))
+		k.SetParamsProvenance(ctx, types.ParamsProvenance{
+			Authority: authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+			Source:    types.ParamSourceGovernance,
+			Reference: proposalReference(ctx, gk, proposal),
+		})
+		return nil
+	}
+}
+
+// proposalReference returns the ID of the passing proposal carrying content.
+// Gov runs the handler while the proposal is still in the active queue, at a
+// block time past its voting end.
+func proposalReference(ctx sdk.Context, gk types.GovKeeper, content *paramproposal.ParameterChangeProposal) string {
+	var reference string
+	gk.IterateActiveProposalsQueue(ctx, ctx.BlockTime(), func(proposal govtypes.Proposal) bool {
+		if other, ok := proposal.GetContent().(*paramproposal.ParameterChangeProposal); !ok || !proto.Equal(other, content) {
+			return false
+		}
+
+		reference = strconv.FormatUint(proposal.ProposalId, 10)
+		return true
+	})
+
+	return reference
+}
+
+func changesFeeParams(proposal *paramproposal.ParameterChangeProposal) bool {
+	for _, change := range proposal.Changes {
+		if change.Subspace == types.ModuleName {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,11 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeeModuleAccount is a module account used by the fee module.
+type FeeModuleAccount struct {
+	Name    string         `json:"name" yaml:"name"`
+	Address sdk.AccAddress `json:"address" yaml:"address"`
+}
@@ -0,0 +1,60 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeeAllocation records where the fee paid by a single tx went. Burned only
+// covers denoms routed to the burn destination; the share of the collector
+// balance burned at the end of the block is not attributed to txs.
+type FeeAllocation struct {
+	TxHash        string         `json:"tx_hash" yaml:"tx_hash"`
+	Height        int64          `json:"height" yaml:"height"`
+	Collected     sdk.Coins      `json:"collected" yaml:"collected"`
+	Burned        sdk.Coins      `json:"burned" yaml:"burned"`
+	CommunityPool sdk.Coins      `json:"community_pool" yaml:"community_pool"`
+	Recipients    []FeeRecipient `json:"recipients" yaml:"recipients"`
+}
+
+// Add returns the allocation with the amounts of other added to it.
+func (a FeeAllocation) Add(other FeeAllocation) FeeAllocation {
+	a.Collected = a.Collected.Add(other.Collected...)
+	a.Burned = a.Burned.Add(other.Burned...)
+	a.CommunityPool = a.CommunityPool.Add(other.CommunityPool...)
+	a.Recipients = append(append([]FeeRecipient{}, a.Recipients...), other.Recipients...)
+	return a
+}
+
+// ComputeFeeAllocation splits fee the way the fee deduction does: denoms with
+// a destination policy are routed first, then the fee splits are paid out of
+// the rest and the remainder is collected.
+func ComputeFeeAllocation(params FeeParams, fee sdk.Coins) FeeAllocation {
+	allocation := FeeAllocation{
+		Burned:        sdk.NewCoins(),
+		CommunityPool: sdk.NewCoins(),
+	}
+
+	remainder := sdk.NewCoins()
+	for _, coin := range fee {
+		switch params.Destination(coin.Denom) {
+		case DestinationBurn:
+			allocation.Burned = allocation.Burned.Add(coin)
+		case DestinationCommunityPool:
+			allocation.CommunityPool = allocation.CommunityPool.Add(coin)
+		default:
+			remainder = remainder.Add(coin)
+		}
+	}
+
+	shares, dust, remainder := AllocateFeeSplits(params, remainder)
+	allocation.Burned = allocation.Burned.Add(dust...)
+	for i, split := range params.FeeSplits {
+		if shares[i].Empty() {
+			continue
+		}
+		allocation.Recipients = append(allocation.Recipients, FeeRecipient{Address: split.Address, Amount: shares[i]})
+	}
+	allocation.Collected = remainder
+
+	return allocation
+}
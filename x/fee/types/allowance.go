@@ -0,0 +1,12 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AllowanceSpent is the fee waived by a fee allowance pool in its current
+// period.
+type AllowanceSpent struct {
+	Period uint64    `json:"period" yaml:"period"`
+	Spent  sdk.Coins `json:"spent" yaml:"spent"`
+}
@@ -8,5 +8,18 @@ import (
 
 // x/fee module sentinel errors
 var (
-	ErrSample = sdkerrors.Register(ModuleName, 1100, "sample error")
+	ErrSample             = sdkerrors.Register(ModuleName, 1100, "sample error")
+	ErrNoMessages         = sdkerrors.Register(ModuleName, 1101, "tx must contain at least one message")
+	ErrNoOracle           = sdkerrors.Register(ModuleName, 1102, "no oracle set for fee conversion")
+	ErrFeeDenomNotAllowed = sdkerrors.Register(ModuleName, 1103, "fee denom not allowed")
+	ErrGasUnderdeclared   = sdkerrors.Register(ModuleName, 1104, "gas limit too low for tx size")
+	ErrParamChangeTooSoon = sdkerrors.Register(ModuleName, 1105, "fee params changed too recently")
+	ErrSponsorCapExceeded = sdkerrors.Register(ModuleName, 1106, "sponsor per-block fee spend cap exceeded")
+	ErrPriorityTooLow     = sdkerrors.Register(ModuleName, 1107, "tx priority below mempool minimum")
+	ErrZeroFee            = sdkerrors.Register(ModuleName, 1108, "tx must pay a non-zero fee")
+	ErrMemoRequired       = sdkerrors.Register(ModuleName, 1109, "tx paying this fee must have a memo")
+	ErrAllowanceExhausted = sdkerrors.Register(ModuleName, 1110, "fee allowance pool exhausted")
+	ErrFeeTooLarge        = sdkerrors.Register(ModuleName, 1111, "fee exceeds the allowed fraction of the payer's balance")
+	ErrDenomNotSpendable  = sdkerrors.Register(ModuleName, 1112, "fee denom not spendable by payer")
+	ErrParamsNotSet       = sdkerrors.Register(ModuleName, 1113, "fee params not set")
 )
@@ -0,0 +1,46 @@
+package types
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// fee module event types
+const (
+	EventTypeBurnFees        = "burn_fees"
+	EventTypeFeePreview      = "fee_preview"
+	EventTypeFeeDeducted     = "fee_deducted"
+	EventTypeGasPrice        = "fee_gas_price"
+	EventTypeOverpaid        = "fee_overpaid"
+	EventTypeGenesis         = "fee_genesis"
+	EventTypeBlockFeeSummary = "block_fee_summary"
+	EventTypeReclaimEscrow   = "fee_reclaim_escrow"
+	EventTypeGasRefund       = "fee_gas_refund"
+	EventTypePoolFallback    = "fee_community_pool_fallback"
+	EventTypeSweepDust       = "fee_sweep_dust"
+	EventTypeGasPenalty      = "fee_gas_penalty"
+
+	AttributeKeyAmount      = "amount"
+	AttributeKeyRequiredFee = "required_fee"
+	AttributeKeyPayer       = "payer"
+	AttributeKeyFee         = "fee"
+	AttributeKeyGas         = "gas"
+	AttributeKeyGasPrice    = "gas_price"
+	AttributeKeyOverpaid    = "overpaid"
+	AttributeKeyMinGasPrice = "min_gas_prices"
+	AttributeKeyBurnRate    = "burn_rate"
+	AttributeKeyMinBurn     = "min_burn_amount"
+	AttributeKeyFeeMode     = "fee_mode"
+	AttributeKeyBurned      = "burned"
+	AttributeKeyCollected   = "collected"
+	AttributeKeyRefund      = "refund"
+	AttributeKeyDenom       = "denom"
+	AttributeKeyError       = "error"
+	AttributeKeyWithheld    = "withheld"
+)
+
+// NewIndexedAttribute returns an event attribute marked for indexing by the
+// node's tx indexer. Nodes configured with an explicit index-events list
+// index what that list says instead.
+func NewIndexedAttribute(key, value string) abci.EventAttribute {
+	return abci.EventAttribute{Key: []byte(key), Value: []byte(value), Index: true}
+}
@@ -0,0 +1,359 @@
+package types
+
+import (
+	"math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// ComputeRequiredFees multiplies each minimum gas price by the gas limit,
+// where fee = ceil(minGasPrice * gasLimit).
+func ComputeRequiredFees(minGasPrices sdk.DecCoins, gas uint64) sdk.Coins {
+	requiredFees := make(sdk.Coins, len(minGasPrices))
+
+	glDec := sdk.NewDec(int64(gas))
+	for i, gp := range minGasPrices {
+		fee := gp.Amount.Mul(glDec)
+		requiredFees[i] = sdk.NewCoin(gp.Denom, fee.Ceil().RoundInt())
+	}
+
+	return requiredFees
+}
+
+// RequiredFeeBreakdown is the fee required from a tx, per component. The
+// components are computed independently, each rounded up, and summed in
+// order: gas, msgs, bytes, signatures, then the fees of registered
+// MsgFeeCalculators. The total is then raised to the fee minimums, see
+// FeeParams.ApplyFeeMinimums.
+type RequiredFeeBreakdown struct {
+	Gas        sdk.Coins `json:"gas" yaml:"gas"`
+	Msgs       sdk.Coins `json:"msgs" yaml:"msgs"`
+	Bytes      sdk.Coins `json:"bytes" yaml:"bytes"`
+	Signatures sdk.Coins `json:"signatures" yaml:"signatures"`
+	Dynamic    sdk.Coins `json:"dynamic" yaml:"dynamic"`
+	Total      sdk.Coins `json:"total" yaml:"total"`
+}
+
+// MsgFeeCalculator computes the fee a msg pays on top of the flat MsgFee from
+// its contents, e.g. a fee proportional to the amount a mint creates.
+type MsgFeeCalculator interface {
+	ComputeMsgFee(ctx sdk.Context, msg sdk.Msg) sdk.Coins
+}
+
+// MsgTypeURL returns the type URL of msg, as used to register
+// MsgFeeCalculators and list ZeroFeeExemptMsgs.
+func MsgTypeURL(msg sdk.Msg) string {
+	return "/" + proto.MessageName(msg)
+}
+
+// ComputeRequiredFeeBreakdown computes the required fee of a tx with the given
+// gas limit, number of msgs, encoded size and number of signatures.
+func ComputeRequiredFeeBreakdown(params FeeParams, minGasPrices sdk.DecCoins, gas uint64, msgs, bytes, signatures int) RequiredFeeBreakdown {
+	breakdown := RequiredFeeBreakdown{
+		Gas:        FeeFromGasPrice(minGasPrices, gas),
+		Msgs:       multiplyCoins(params.MsgFee, msgs),
+		Bytes:      FeeFromGasPrice(params.ByteFee, uint64(bytes)),
+		Signatures: multiplyCoins(params.SignatureFee, signatures),
+		Dynamic:    sdk.NewCoins(),
+	}
+
+	breakdown.Total = params.ApplyFeeMinimums(breakdown.Sum())
+
+	return breakdown
+}
+
+// Sum returns the sum of the fee components, before any floor.
+func (b RequiredFeeBreakdown) Sum() sdk.Coins {
+	return b.Gas.Add(b.Flat()...)
+}
+
+// Flat returns the flat components of the breakdown, the fee that must be
+// paid in its own denoms whichever denom pays the gas fee.
+func (b RequiredFeeBreakdown) Flat() sdk.Coins {
+	return b.Msgs.
+		Add(b.Bytes...).
+		Add(b.Signatures...).
+		Add(b.Dynamic...)
+}
+
+// PayableFee returns the fee paying the breakdown: its total, with the gas fee
+// only paid in the first denom with a min gas price. Any of those denoms
+// covers the gas fee, while the other components must be paid in full.
+func (b RequiredFeeBreakdown) PayableFee() sdk.Coins {
+	return b.PayableOptions()[0]
+}
+
+// PayableOptions returns the fees paying the breakdown, one per denom with a
+// min gas price paying the gas fee, with the flat components paid in full in
+// their own denoms. Without a gas fee the only option is the whole total.
+func (b RequiredFeeBreakdown) PayableOptions() []sdk.Coins {
+	if len(b.Gas) == 0 {
+		return []sdk.Coins{b.Total}
+	}
+
+	options := make([]sdk.Coins, len(b.Gas))
+	for i, coin := range b.Gas {
+		options[i] = b.payableIn(coin.Denom)
+	}
+
+	return options
+}
+
+// IsMetBy reports whether fee pays the breakdown in any of its payable
+// options: the gas fee in any one of the denoms with a min gas price, and the
+// flat components in full in their own denoms.
+func (b RequiredFeeBreakdown) IsMetBy(fee sdk.Coins) bool {
+	for _, option := range b.PayableOptions() {
+		if fee.IsAllGTE(option) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Multiply returns the breakdown with every component and the total
+// multiplied by multiplier, rounding up.
+func (b RequiredFeeBreakdown) Multiply(multiplier sdk.Dec) RequiredFeeBreakdown {
+	return RequiredFeeBreakdown{
+		Gas:        MultiplyFee(b.Gas, multiplier),
+		Msgs:       MultiplyFee(b.Msgs, multiplier),
+		Bytes:      MultiplyFee(b.Bytes, multiplier),
+		Signatures: MultiplyFee(b.Signatures, multiplier),
+		Dynamic:    MultiplyFee(b.Dynamic, multiplier),
+		Total:      MultiplyFee(b.Total, multiplier),
+	}
+}
+
+// payableIn returns the total of the breakdown with the gas fee paid in denom:
+// the other denoms with a min gas price are reduced to their flat components.
+func (b RequiredFeeBreakdown) payableIn(denom string) sdk.Coins {
+	flat := b.Flat()
+
+	fee := sdk.NewCoins()
+	for _, coin := range b.Total {
+		if coin.Denom != denom && b.Gas.AmountOf(coin.Denom).IsPositive() {
+			coin.Amount = flat.AmountOf(coin.Denom)
+		}
+		fee = fee.Add(coin)
+	}
+
+	return fee
+}
+
+// RaiseDenomMinimums raises every positive denom of fee to its amount in
+// minimums. Denoms fee lacks are not added.
+func RaiseDenomMinimums(fee, minimums sdk.Coins) sdk.Coins {
+	raised := sdk.NewCoins()
+	for _, coin := range fee {
+		if min := minimums.AmountOf(coin.Denom); coin.IsPositive() && min.GT(coin.Amount) {
+			coin.Amount = min
+		}
+		raised = raised.Add(coin)
+	}
+
+	return raised
+}
+
+// ApplyFeeMinimums raises the required fee to MinFeePerDenom, then to the
+// PerBlockMinFee floor, in the denoms it can be paid in.
+func (p FeeParams) ApplyFeeMinimums(fee sdk.Coins) sdk.Coins {
+	return RaiseDenomMinimums(RaiseDenomMinimums(fee, p.MinFeePerDenom), p.PerBlockMinFee)
+}
+
+func multiplyCoins(coins sdk.Coins, n int) sdk.Coins {
+	product := sdk.NewCoins()
+	if n <= 0 {
+		return product
+	}
+
+	for _, coin := range coins {
+		product = product.Add(sdk.NewCoin(coin.Denom, coin.Amount.MulRaw(int64(n))))
+	}
+
+	return product
+}
+
+// FeeFromGasPrice returns the total fee for a gas price and gas limit,
+// rounded up per denom. Denoms with a zero result are dropped.
+func FeeFromGasPrice(gasPrice sdk.DecCoins, gas uint64) sdk.Coins {
+	fee := sdk.NewCoins()
+	for _, coin := range ComputeRequiredFees(gasPrice, gas) {
+		if coin.IsPositive() {
+			fee = fee.Add(coin)
+		}
+	}
+
+	return fee
+}
+
+// AllocateFeeSplits splits fee under params.FeeSplits like ComputeFeeSplits,
+// then moves the dust lost to truncating the shares as DustHandling directs:
+// it stays in the remainder for the collector, is added to the first split's
+// share, or is returned as burned. Together the shares, burned and remainder
+// always add up to fee.
+func AllocateFeeSplits(params FeeParams, fee sdk.Coins) (shares []sdk.Coins, burned, remainder sdk.Coins) {
+	shares, remainder = ComputeFeeSplits(fee, params.FeeSplits)
+	burned = sdk.NewCoins()
+
+	if len(params.FeeSplits) == 0 {
+		return shares, burned, remainder
+	}
+
+	dust := splitDust(fee, params.FeeSplits, shares)
+	if dust.Empty() {
+		return shares, burned, remainder
+	}
+
+	switch params.DustHandling {
+	case DustToFirstRecipient:
+		shares[0] = shares[0].Add(dust...)
+		remainder = remainder.Sub(dust)
+	case DustBurn:
+		burned = dust
+		remainder = remainder.Sub(dust)
+	}
+
+	return shares, burned, remainder
+}
+
+// splitDust returns the whole units of fee owed to the splits in total that
+// truncating the individual shares left out.
+func splitDust(fee sdk.Coins, splits []FeeSplit, shares []sdk.Coins) sdk.Coins {
+	weight := sdk.ZeroDec()
+	for _, split := range splits {
+		weight = weight.Add(split.Weight)
+	}
+
+	dust := sdk.NewCoins()
+	for _, coin := range fee {
+		owed := coin.Amount.ToDec().Mul(weight).TruncateInt()
+		for _, share := range shares {
+			owed = owed.Sub(share.AmountOf(coin.Denom))
+		}
+		if owed.IsPositive() {
+			dust = dust.Add(sdk.NewCoin(coin.Denom, owed))
+		}
+	}
+
+	return dust
+}
+
+// FallbackFee replaces every coin of fee that spendable does not cover with
+// the fallback denom, at the coin's amount increased by the fallback penalty
+// and rounded up. The fee is returned unchanged without a fallback denom.
+func FallbackFee(params FeeParams, fee, spendable sdk.Coins) sdk.Coins {
+	if params.FallbackDenom == "" {
+		return fee
+	}
+
+	multiplier := sdk.OneDec().Add(decOrZero(params.FallbackPenalty))
+
+	charged := sdk.NewCoins()
+	for _, coin := range fee {
+		if coin.Denom == params.FallbackDenom || spendable.AmountOf(coin.Denom).GTE(coin.Amount) {
+			charged = charged.Add(coin)
+			continue
+		}
+
+		amt := coin.Amount.ToDec().Mul(multiplier).Ceil().TruncateInt()
+		charged = charged.Add(sdk.NewCoin(params.FallbackDenom, amt))
+	}
+
+	return charged
+}
+
+// TxPriority returns the priority of a tx paying fee for gas: the lowest gas
+// price paid across the fee denoms priced in minGasPrices, truncated to an
+// integer. Denoms without a min gas price do not count, unless there are no
+// min gas prices at all. A tx without a fee, gas or a priced denom has
+// priority zero.
+func TxPriority(fee sdk.Coins, gas uint64, minGasPrices sdk.DecCoins) int64 {
+	if fee.Empty() || gas == 0 {
+		return 0
+	}
+
+	maxPriority := sdk.NewDec(math.MaxInt64)
+	gasDec := sdk.NewIntFromUint64(gas).ToDec()
+
+	var lowest sdk.Dec
+	for _, coin := range fee {
+		if !minGasPrices.Empty() && minGasPrices.AmountOf(coin.Denom).IsZero() {
+			continue
+		}
+
+		gasPrice := coin.Amount.ToDec().Quo(gasDec)
+		if lowest.IsNil() || gasPrice.LT(lowest) {
+			lowest = gasPrice
+		}
+	}
+
+	if lowest.IsNil() {
+		return 0
+	}
+
+	return sdk.MinDec(lowest, maxPriority).TruncateInt64()
+}
+
+// SuggestFeeBump returns, for every denom of requiredFees, the larger of the
+// required and current fee increased by bumpRate, rounded up.
+func SuggestFeeBump(requiredFees, currentFee sdk.Coins, bumpRate sdk.Dec) sdk.Coins {
+	multiplier := sdk.OneDec().Add(decOrZero(bumpRate))
+
+	suggested := sdk.NewCoins()
+	for _, fee := range requiredFees {
+		base := sdk.MaxInt(fee.Amount, currentFee.AmountOf(fee.Denom))
+		suggested = suggested.Add(sdk.NewCoin(fee.Denom, base.ToDec().Mul(multiplier).Ceil().TruncateInt()))
+	}
+
+	return suggested
+}
+
+// ComputeFeeSplits returns the share of fee owed to each split, truncated, and
+// the remainder left over.
+func ComputeFeeSplits(fee sdk.Coins, splits []FeeSplit) ([]sdk.Coins, sdk.Coins) {
+	shares := make([]sdk.Coins, len(splits))
+	remainder := fee
+
+	for i, split := range splits {
+		share := sdk.NewCoins()
+		for _, coin := range fee {
+			amt := coin.Amount.ToDec().Mul(split.Weight).TruncateInt()
+			if amt.IsPositive() {
+				share = share.Add(sdk.NewCoin(coin.Denom, amt))
+			}
+		}
+		shares[i] = share
+		remainder = remainder.Sub(share)
+	}
+
+	return shares, remainder
+}
+
+// MultiplyFee multiplies every coin of fee by multiplier, rounding up.
+func MultiplyFee(fee sdk.Coins, multiplier sdk.Dec) sdk.Coins {
+	product := sdk.NewCoins()
+	for _, coin := range fee {
+		product = product.Add(sdk.NewCoin(coin.Denom, coin.Amount.ToDec().Mul(multiplier).Ceil().TruncateInt()))
+	}
+
+	return product
+}
+
+// UnusedGasRefund returns the part of the prepaid fee that paid for the gas
+// left unused out of gasWanted: prepaid less the gas fee actually used,
+// prepaid * gasUsed / gasWanted rounded up per denom.
+func UnusedGasRefund(prepaid sdk.Coins, gasWanted, gasUsed uint64) sdk.Coins {
+	if gasWanted == 0 || gasUsed >= gasWanted {
+		return sdk.NewCoins()
+	}
+
+	used := sdk.NewDecFromInt(sdk.NewIntFromUint64(gasUsed)).Quo(sdk.NewDecFromInt(sdk.NewIntFromUint64(gasWanted)))
+	charged := sdk.NewCoins()
+	for _, coin := range prepaid {
+		charged = charged.Add(sdk.NewCoin(coin.Denom, coin.Amount.ToDec().Mul(used).Ceil().TruncateInt()))
+	}
+
+	return prepaid.Sub(charged)
+}
@@ -0,0 +1,76 @@
+package types_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestFeeFromGasPrice(t *testing.T) {
+	gasPrice := sdk.DecCoins{
+		sdk.NewDecCoinFromDec("atom", sdk.NewDecWithPrec(25, 3)),
+		sdk.NewDecCoinFromDec("stake", sdk.ZeroDec()),
+		sdk.NewDecCoinFromDec("uosmo", sdk.NewDecWithPrec(15, 1)),
+	}
+
+	// 0.025 * 100001 = 2500.025 rounds up, and the zero priced denom is dropped
+	require.Equal(t,
+		sdk.NewCoins(sdk.NewInt64Coin("atom", 2501), sdk.NewInt64Coin("uosmo", 150002)),
+		types.FeeFromGasPrice(gasPrice, 100001),
+	)
+}
+
+func TestTxPriority(t *testing.T) {
+	minGasPrices := sdk.NewDecCoins(sdk.NewDecCoin("atom", sdk.OneInt()), sdk.NewDecCoin("stake", sdk.OneInt()))
+	fee := sdk.NewCoins(sdk.NewInt64Coin("atom", 2500), sdk.NewInt64Coin("stake", 1999), sdk.NewInt64Coin("uosmo", 1))
+
+	// the lowest priced gas price, 1999/1000, truncated; uosmo is not priced
+	require.Equal(t, int64(1), types.TxPriority(fee, 1000, minGasPrices))
+	require.Equal(t, int64(0), types.TxPriority(fee, 1000, nil))
+	require.Equal(t, int64(0), types.TxPriority(sdk.NewCoins(sdk.NewInt64Coin("uosmo", 5000)), 1000, minGasPrices))
+
+	// gas limits beyond int64 do not overflow
+	require.Equal(t, int64(0), types.TxPriority(fee, math.MaxUint64, minGasPrices))
+}
+
+func TestRequiredFeeBreakdownPayableFee(t *testing.T) {
+	params := types.DefaultParams()
+	minGasPrices := sdk.NewDecCoins(
+		sdk.NewDecCoinFromDec("atom", sdk.NewDecWithPrec(1, 1)),
+		sdk.NewDecCoinFromDec("stake", sdk.NewDec(5)),
+	)
+
+	// the gas fee, estimated gas times price, is paid in the first denom only
+	breakdown := types.ComputeRequiredFeeBreakdown(params, minGasPrices, 181462, 1, 0, 0)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("atom", 18147)), breakdown.PayableFee())
+
+	// flat fees are paid in full in every denom
+	params.MsgFee = sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	breakdown = types.ComputeRequiredFeeBreakdown(params, minGasPrices, 181462, 1, 0, 0)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("atom", 18147), sdk.NewInt64Coin("stake", 100)), breakdown.PayableFee())
+}
+
+func TestRequiredFeeBreakdownRequiresFlatFeeOfZeroPricedDenom(t *testing.T) {
+	params := types.DefaultParams()
+	params.MsgFee = sdk.NewCoins(sdk.NewInt64Coin("atom", 100))
+	minGasPrices := sdk.DecCoins{
+		sdk.NewDecCoinFromDec("atom", sdk.ZeroDec()),
+		sdk.NewDecCoinFromDec("stake", sdk.NewDec(5)),
+	}
+	breakdown := types.ComputeRequiredFeeBreakdown(params, minGasPrices, 100000, 1, 0, 0)
+
+	// atom pays no gas fee, but its flat fee is required next to the gas fee
+	require.False(t, breakdown.IsMetBy(sdk.NewCoins(sdk.NewInt64Coin("stake", 500000))))
+	require.False(t, breakdown.IsMetBy(sdk.NewCoins(sdk.NewInt64Coin("atom", 100))))
+	require.False(t, breakdown.IsMetBy(sdk.NewCoins(sdk.NewInt64Coin("atom", 99), sdk.NewInt64Coin("stake", 500000))))
+	require.True(t, breakdown.IsMetBy(sdk.NewCoins(sdk.NewInt64Coin("atom", 100), sdk.NewInt64Coin("stake", 500000))))
+
+	// without a gas fee the flat fee alone is required
+	breakdown = types.ComputeRequiredFeeBreakdown(params, nil, 100000, 1, 0, 0)
+	require.False(t, breakdown.IsMetBy(sdk.NewCoins(sdk.NewInt64Coin("stake", 500000))))
+	require.True(t, breakdown.IsMetBy(sdk.NewCoins(sdk.NewInt64Coin("atom", 100))))
+}
@@ -10,6 +10,7 @@ const DefaultIndex uint64 = 1
 // DefaultGenesis returns the default Capability genesis state
 func DefaultGenesis() *GenesisState {
 	return &GenesisState{
+		Params: GenesisParams{FeeParams: DefaultParams()},
 		// this line is used by starport scaffolding # genesis/types/default
 	}
 }
@@ -19,5 +20,53 @@ func DefaultGenesis() *GenesisState {
 func (gs GenesisState) Validate() error {
 	// this line is used by starport scaffolding # genesis/types/validate
 
-	return nil
+	return ValidateFee(gs.Params.FeeParams)
+}
+
+// GenesisParams are the fee params of the genesis state. FeeParams is not a
+// protobuf message, so they are encoded the way the param store encodes them:
+// amino JSON in genesis files and amino binary otherwise.
+type GenesisParams struct {
+	FeeParams
+}
+
+// Marshal implements the gogoproto custom type interface.
+func (p GenesisParams) Marshal() ([]byte, error) {
+	return ModuleCdc.LegacyAmino.MarshalBinaryBare(p.FeeParams)
+}
+
+// MarshalTo implements the gogoproto custom type interface.
+func (p *GenesisParams) MarshalTo(data []byte) (int, error) {
+	bz, err := p.Marshal()
+	if err != nil {
+		return 0, err
+	}
+
+	return copy(data, bz), nil
+}
+
+// Unmarshal implements the gogoproto custom type interface.
+func (p *GenesisParams) Unmarshal(data []byte) error {
+	if len(data) == 0 {
+		p.FeeParams = FeeParams{}
+		return nil
+	}
+
+	return ModuleCdc.LegacyAmino.UnmarshalBinaryBare(data, &p.FeeParams)
+}
+
+// Size implements the gogoproto custom type interface.
+func (p *GenesisParams) Size() int {
+	bz, _ := p.Marshal()
+	return len(bz)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (p GenesisParams) MarshalJSON() ([]byte, error) {
+	return ModuleCdc.LegacyAmino.MarshalJSON(p.FeeParams)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *GenesisParams) UnmarshalJSON(data []byte) error {
+	return ModuleCdc.LegacyAmino.UnmarshalJSON(data, &p.FeeParams)
 }
@@ -5,6 +5,7 @@ package types
 
 import (
 	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	io "io"
 	math "math"
@@ -24,6 +25,9 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 // GenesisState defines the capability module's genesis state.
 type GenesisState struct {
+	// params are the fee params set at genesis. They are not a protobuf
+	// message and are encoded as in the param store, see GenesisParams.
+	Params GenesisParams `protobuf:"bytes,1,opt,name=params,proto3,customtype=GenesisParams" json:"params"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
@@ -66,16 +70,18 @@ func init() {
 func init() { proto.RegisterFile("fee/genesis.proto", fileDescriptor_d516c270f8b2488e) }
 
 var fileDescriptor_d516c270f8b2488e = []byte{
-	// 131 bytes of a gzipped FileDescriptorProto
+	// 175 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x4c, 0x4b, 0x4d, 0xd5,
 	0x4f, 0x4f, 0xcd, 0x4b, 0x2d, 0xce, 0x2c, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0xca,
-	0x4d, 0x2c, 0x4a, 0x4a, 0x2c, 0x32, 0x36, 0x37, 0xb7, 0xd0, 0x4b, 0x4b, 0x4d, 0x05, 0x61, 0x25,
-	0x3e, 0x2e, 0x1e, 0x77, 0x88, 0xa2, 0xe0, 0x92, 0xc4, 0x92, 0x54, 0x27, 0xfb, 0x13, 0x8f, 0xe4,
-	0x18, 0x2f, 0x3c, 0x92, 0x63, 0x7c, 0xf0, 0x48, 0x8e, 0x71, 0xc2, 0x63, 0x39, 0x86, 0x0b, 0x8f,
-	0xe5, 0x18, 0x6e, 0x3c, 0x96, 0x63, 0x88, 0x52, 0x4d, 0xcf, 0x2c, 0xc9, 0x28, 0x4d, 0xd2, 0x4b,
-	0xce, 0xcf, 0xd5, 0x47, 0x18, 0xa4, 0x0f, 0xb2, 0xa6, 0x02, 0x4c, 0x96, 0x54, 0x16, 0xa4, 0x16,
-	0x27, 0xb1, 0x81, 0xed, 0x32, 0x06, 0x04, 0x00, 0x00, 0xff, 0xff, 0x5a, 0x40, 0xaf, 0xef, 0x80,
-	0x00, 0x00, 0x00,
+	0x4d, 0x2c, 0x4a, 0x4a, 0x2c, 0x32, 0x36, 0x37, 0xb7, 0xd0, 0x4b, 0x4b, 0x4d, 0x05, 0x61, 0x29,
+	0x91, 0xf4, 0xfc, 0xf4, 0x7c, 0xb0, 0xb4, 0x3e, 0x88, 0x05, 0x51, 0xa9, 0x64, 0xcb, 0xc5, 0xe3,
+	0x0e, 0xd1, 0x1a, 0x5c, 0x92, 0x58, 0x92, 0x2a, 0xa4, 0xcb, 0xc5, 0x56, 0x90, 0x58, 0x94, 0x98,
+	0x5b, 0x2c, 0xc1, 0xa8, 0xc0, 0xa8, 0xc1, 0xe3, 0x24, 0x7a, 0xe2, 0x9e, 0x3c, 0xc3, 0xad, 0x7b,
+	0xf2, 0xbc, 0x50, 0x55, 0x01, 0x60, 0xc9, 0x20, 0xa8, 0x22, 0x27, 0xfb, 0x13, 0x8f, 0xe4, 0x18,
+	0x2f, 0x3c, 0x92, 0x63, 0x7c, 0xf0, 0x48, 0x8e, 0x71, 0xc2, 0x63, 0x39, 0x86, 0x0b, 0x8f, 0xe5,
+	0x18, 0x6e, 0x3c, 0x96, 0x63, 0x88, 0x52, 0x4d, 0xcf, 0x2c, 0xc9, 0x28, 0x4d, 0xd2, 0x4b, 0xce,
+	0xcf, 0xd5, 0x47, 0xb8, 0x46, 0x1f, 0xe4, 0xd6, 0x0a, 0x30, 0x59, 0x52, 0x59, 0x90, 0x5a, 0x9c,
+	0xc4, 0x06, 0x76, 0x86, 0x31, 0x60, 0x00, 0xf4, 0x4c, 0x01, 0x43, 0xc5, 0x00, 0x00, 0x00,
 }
 
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
@@ -98,6 +104,16 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	{
+		size := m.Params.Size()
+		i -= size
+		if _, err := m.Params.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
 }
 
@@ -118,6 +134,8 @@ func (m *GenesisState) Size() (n int) {
 	}
 	var l int
 	_ = l
+	l = m.Params.Size()
+	n += 1 + l + sovGenesis(uint64(l))
 	return n
 }
 
@@ -156,6 +174,39 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: GenesisState: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -15,6 +15,79 @@ const (
 
 	// MemStoreKey defines the in-memory store key
 	MemStoreKey = "mem_capability"
+
+	// TStoreKey defines the transient store key, reset every block
+	TStoreKey = "transient_fee"
+
+	// BlockFeeStatsKey is the store prefix for per-block fee statistics
+	BlockFeeStatsKey = "BlockFeeStats-value-"
+
+	// BaseFeeKey is the store key of the dynamic base fee
+	BaseFeeKey = "BaseFee-value-"
+
+	// LastBlockGasKey is the store key of the gas used by the previous block
+	LastBlockGasKey = "LastBlockGas-value-"
+
+	// FeeAllocationKey is the store prefix for per-tx fee allocations
+	FeeAllocationKey = "FeeAllocation-value-"
+
+	// FeeAllocationHeightKey is the store prefix indexing the per-tx fee
+	// allocations by height, for pruning
+	FeeAllocationHeightKey = "FeeAllocationHeight-value-"
+
+	// TotalBurnedKey is the store key of the cumulative finalized burns
+	TotalBurnedKey = "TotalBurned-value-"
+
+	// PendingBurnKey is the store prefix for burns awaiting confirmation
+	PendingBurnKey = "PendingBurn-value-"
+
+	// LastParamChangeKey is the store key of the height the fee params last
+	// changed at
+	LastParamChangeKey = "LastParamChange-value-"
+
+	// SponsorSpentKey is the transient store prefix for the fees paid by
+	// sponsors in the current block
+	SponsorSpentKey = "SponsorSpent-value-"
+
+	// GenesisTxsKey is the transient store key marking that the genesis txs
+	// are being delivered
+	GenesisTxsKey = "GenesisTxs-value-"
+
+	// FeeReceiptKey is the transient store key of the fee receipt of the tx
+	// being delivered
+	FeeReceiptKey = "FeeReceipt-value-"
+
+	// GasChargeKey is the transient store key of the gas charge of the tx
+	// being delivered
+	GasChargeKey = "GasCharge-value-"
+
+	// GasRefundKey is the transient store prefix for the unused gas refunds
+	// queued in the current block, by delivery order
+	GasRefundKey = "GasRefund-value-"
+
+	// GasRefundCountKey is the transient store key of the number of unused
+	// gas refunds queued in the current block
+	GasRefundCountKey = "GasRefundCount-value-"
+
+	// ConversionRateKey is the transient store prefix for the oracle
+	// conversion rates fetched in the current block
+	ConversionRateKey = "ConversionRate-value-"
+
+	// ExcludedCollectorBalanceKey is the store key of the fee collector
+	// balance that predates the fee module and is never burned
+	ExcludedCollectorBalanceKey = "ExcludedCollectorBalance-value-"
+
+	// DenomRemovalKey is the store prefix for the height a fee denom was
+	// removed from the whitelist at
+	DenomRemovalKey = "DenomRemoval-value-"
+
+	// AllowanceSpentKey is the store prefix for the fees waived by every fee
+	// allowance pool in its current period
+	AllowanceSpentKey = "AllowanceSpent-value-"
+
+	// ParamsProvenanceKey is the store key of the change that set the
+	// current fee params
+	ParamsProvenanceKey = "ParamsProvenance-value-"
 )
 
 func KeyPrefix(p string) []byte {
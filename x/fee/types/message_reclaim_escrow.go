@@ -0,0 +1,45 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+var _ sdk.Msg = &MsgReclaimEscrow{}
+
+// NewMsgReclaimEscrow returns a msg sweeping the fee module account to the
+// fee collector.
+func NewMsgReclaimEscrow(authority string) *MsgReclaimEscrow {
+	return &MsgReclaimEscrow{
+		Authority: authority,
+	}
+}
+
+func (msg *MsgReclaimEscrow) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgReclaimEscrow) Type() string {
+	return "ReclaimEscrow"
+}
+
+func (msg *MsgReclaimEscrow) GetSigners() []sdk.AccAddress {
+	authority, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{authority}
+}
+
+func (msg *MsgReclaimEscrow) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgReclaimEscrow) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid authority address (%s)", err)
+	}
+
+	return nil
+}
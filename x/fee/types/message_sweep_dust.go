@@ -0,0 +1,45 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+var _ sdk.Msg = &MsgSweepDust{}
+
+// NewMsgSweepDust returns a msg moving the dust left in the fee module
+// accounts to the fee collector.
+func NewMsgSweepDust(authority string) *MsgSweepDust {
+	return &MsgSweepDust{
+		Authority: authority,
+	}
+}
+
+func (msg *MsgSweepDust) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgSweepDust) Type() string {
+	return "SweepDust"
+}
+
+func (msg *MsgSweepDust) GetSigners() []sdk.AccAddress {
+	authority, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{authority}
+}
+
+func (msg *MsgSweepDust) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgSweepDust) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid authority address (%s)", err)
+	}
+
+	return nil
+}
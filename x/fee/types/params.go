@@ -0,0 +1,903 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+)
+
+var (
+	ParamStoreKeyfee  = []byte("fee")
+	ParamStoreKeyburn = []byte("burn")
+)
+
+// MaxDenomExponent is the largest decimal exponent of a denom unit fees are
+// scaled by, keeping the scaled amounts within sdk.Int.
+const MaxDenomExponent uint32 = 18
+
+// DefaultStatsRetentionBlocks is the default number of blocks the block fee
+// stats and fee allocations are kept for, about a week of 6 second blocks.
+const DefaultStatsRetentionBlocks uint64 = 100800
+
+// fee modes
+const (
+	// FeeModeGas charges the provided fee, checked against the min gas prices.
+	FeeModeGas = "gas"
+	// FeeModeValue charges only as much of the provided fee as is needed to
+	// cover ValueGasPrice * gas, valued through the oracle.
+	FeeModeValue = "value"
+)
+
+// fee destinations
+const (
+	DestinationCollector     = "collector"
+	DestinationBurn          = "burn"
+	DestinationCommunityPool = "community_pool"
+)
+
+// dust handling policies, deciding where the units lost to truncating fee
+// split shares go
+const (
+	DustToCollector      = "to_collector"
+	DustToFirstRecipient = "to_first_recipient"
+	DustBurn             = "burn"
+)
+
+// conversion rounding directions, deciding who keeps the fraction of a coin
+// left when an oracle conversion is rounded to whole coins
+const (
+	// RoundingCeil rounds in the chain's favor.
+	RoundingCeil = "ceil"
+	// RoundingFloor rounds in the user's favor.
+	RoundingFloor = "floor"
+)
+
+// event verbosity levels, controlling which fee events the ante decorators emit
+const (
+	EventVerbosityNone    = "none"
+	EventVerbosityMinimal = "minimal"
+	EventVerbosityFull    = "full"
+)
+
+type FeeParams struct {
+	Fee        sdk.DecCoins
+	BurnAmount sdk.Int
+	// BurnRate is the fraction of the fee collector balance burned every block.
+	BurnRate sdk.Dec
+	// MinBurnAmount is the smallest amount of a denom burned in a block. A
+	// computed burn below it is either rounded up to it or skipped, depending
+	// on RoundUpBurn.
+	MinBurnAmount sdk.Int
+	RoundUpBurn   bool
+	// StatsRetentionBlocks is the number of blocks the block fee stats and
+	// fee allocations are kept for before they are pruned. Zero keeps them
+	// forever.
+	StatsRetentionBlocks uint64
+	// AllowedDenoms whitelists the denoms fees can be paid in. An empty list
+	// accepts any denom.
+	AllowedDenoms []string
+	// FeeBumpRate is the fraction a suggested fee bump exceeds the fee
+	// required to replace a stuck tx.
+	FeeBumpRate sdk.Dec
+	// ValidatorMinGasPrices are the local minimum gas prices reported by
+	// validators, used to estimate the floor accepted across the network.
+	ValidatorMinGasPrices []ValidatorMinGasPrice
+	// FeeMode selects how fees are charged, see FeeModeGas and FeeModeValue.
+	FeeMode string
+	// ValueGasPrice is the price per unit of gas, in the oracle's base
+	// denom, charged in FeeModeValue.
+	ValueGasPrice sdk.Dec
+	// EventVerbosity controls the fee events emitted by the ante decorators.
+	// Minimal only emits fee_deducted, full also emits gas price and
+	// overpayment events.
+	EventVerbosity string
+	// FeeSplits send a weighted share of every fee straight to the given
+	// addresses. Together with BurnRate they may not exceed the whole fee.
+	FeeSplits []FeeSplit
+	// BaseFeeEnabled turns on a dynamic base fee that moves every block
+	// towards TargetBlockGas by at most 1/BaseFeeChangeDenominator.
+	BaseFeeEnabled           bool
+	TargetBlockGas           uint64
+	BaseFeeChangeDenominator uint64
+	// MinBaseFee and MaxBaseFee clamp the adjusted base fee per denom.
+	// Denoms missing from either are not clamped on that side.
+	MinBaseFee sdk.DecCoins
+	MaxBaseFee sdk.DecCoins
+	// DestinationPolicies route the whole fee paid in a denom to a
+	// destination. Denoms without a policy go to the fee collector.
+	DestinationPolicies []DestinationPolicy
+	// OffPeakDiscount is the fraction the min gas prices are reduced by while
+	// the previous block used less than OffPeakThreshold of TargetBlockGas.
+	OffPeakDiscount  sdk.Dec
+	OffPeakThreshold sdk.Dec
+	// RecordFeeAllocations stores a FeeAllocation for every tx paying a fee
+	// so it can be queried by tx hash, for StatsRetentionBlocks blocks. Off
+	// by default to bound state growth.
+	RecordFeeAllocations bool
+	// BurnConfirmations is the number of blocks a burn stays pending before
+	// it is added to the cumulative burn total.
+	BurnConfirmations uint64
+	// StateRentRate is the extra gas charged per byte a tx writes to state.
+	StateRentRate uint64
+	// MinGasPerByte is the gas limit a tx must declare per byte of its
+	// encoding, so large txs cannot underpay by declaring little gas.
+	MinGasPerByte uint64
+	// FallbackDenom is charged instead of a fee denom the payer cannot cover,
+	// valued one to one and increased by the FallbackPenalty fraction. An
+	// empty denom disables the fallback.
+	FallbackDenom   string
+	FallbackPenalty sdk.Dec
+	// MinBlocksBetweenParamChanges is the number of blocks that must pass
+	// after a params change before the next one is accepted.
+	MinBlocksBetweenParamChanges uint64
+	// DustHandling selects where the rounding dust of the fee splits goes,
+	// see DustToCollector, DustToFirstRecipient and DustBurn.
+	DustHandling string
+	// SponsorSpendCaps bound the fees a sponsoring account pays per block
+	// across all the txs it pays for.
+	SponsorSpendCaps []SponsorSpendCap
+	// MsgFee, ByteFee and SignatureFee are surcharges required on top of the
+	// gas fee: per message, per byte of the encoded tx and per signature.
+	MsgFee       sdk.Coins
+	ByteFee      sdk.DecCoins
+	SignatureFee sdk.Coins
+	// MinMempoolPriority is the lowest TxPriority accepted into the mempool.
+	MinMempoolPriority int64
+	// RequireNonZeroFee rejects txs paying no fee at all, unless all their
+	// msgs are of a type listed in ZeroFeeExemptMsgs, or in the
+	// ChainZeroFeeExemptions of the chain-id.
+	RequireNonZeroFee      bool
+	ZeroFeeExemptMsgs      []string
+	ChainZeroFeeExemptions []ChainZeroFeeExemption
+	// DenomExponents give the decimal exponent of a fee denom. Min gas prices
+	// in Fee for such a denom are per 10^Exponent base units, e.g. atom
+	// rather than uatom, and are scaled to base units by MinGasPrices.
+	DenomExponents []DenomExponent
+	// EmitFeeReceipt appends a FeeReceipt to the result data of every
+	// successful tx paying a fee.
+	EmitFeeReceipt bool
+	// RefundUnusedGas refunds, at the end of the block, the part of the fee
+	// reaching the fee collector that paid for gas a tx did not use, whether
+	// the tx succeeded or failed. The collector is burned down only after
+	// the refunds, so the burn applies to the charged part alone.
+	RefundUnusedGas bool
+	// GraceBlocksAfterDenomRemoval keeps accepting fees in a denom removed
+	// from AllowedDenoms for this many blocks after the removal, letting
+	// wallets migrate.
+	GraceBlocksAfterDenomRemoval uint64
+	// PerBlockMinFee is the least fee a tx pays for its block space, however
+	// little gas it declares. It is a floor on the total required fee in each
+	// denom the tx can pay in, not an extra charge, and does not make a denom
+	// payable.
+	PerBlockMinFee sdk.Coins
+	// MinFeePerDenom is the least fee paid in a denom, however little gas a
+	// tx declares. Like PerBlockMinFee it only raises the fee required in
+	// denoms a tx can already pay in.
+	MinFeePerDenom sdk.Coins
+	// Once the previous block used more than LoadSheddingThreshold of
+	// TargetBlockGas, the fee required for mempool admission is multiplied by
+	// LoadSheddingMultiplier to shed load. A zero threshold disables it.
+	LoadSheddingThreshold  sdk.Dec
+	LoadSheddingMultiplier sdk.Dec
+	// RequireMemoAboveFee rejects txs without a memo paying more than this
+	// fee in any of its denoms.
+	RequireMemoAboveFee sdk.Coins
+	// KeepMinSupply skips fee burns that would take the supply of a denom
+	// below its minimum; the fees stay with the fee collector instead.
+	KeepMinSupply []MinSupply
+	// FeeAllowancePools let their members send txs paying no fee until the
+	// required fees waived for them add up to the pool's allowance, in every
+	// period of the pool.
+	FeeAllowancePools []FeeAllowancePool
+	// ConversionRounding selects how amounts converted through the oracle
+	// are rounded to whole coins, see RoundingCeil and RoundingFloor.
+	ConversionRounding string
+	// MaxFeeFractionOfBalance rejects fees above this fraction of the
+	// payer's spendable balance in any denom, guarding against fat-fingered
+	// fees that drain an account. Zero disables the check.
+	MaxFeeFractionOfBalance sdk.Dec
+	// FeeExemptPayers are the bech32 addresses of fee payers exempt from
+	// fees: their txs need not meet the required fee.
+	FeeExemptPayers []string
+	// ExemptHonorsExplicitFee charges FeeExemptPayers the fee they
+	// explicitly set. When false exempt payers are never charged, whatever
+	// fee they set.
+	ExemptHonorsExplicitFee bool
+	// BurnOnCommunityPoolFailure burns the fees routed to the community pool
+	// when funding it fails, instead of failing the tx.
+	BurnOnCommunityPoolFailure bool
+	// EventTypePrefix namespaces the type of every fee event, e.g. the
+	// prefix "mychain" turns fee_deducted into mychain.fee_deducted. Events
+	// are unprefixed when it is empty.
+	EventTypePrefix string
+	// DustSweepThreshold is, per denom, the balance below which the coins
+	// left in the fee module accounts are dust that SweepDust moves to the
+	// fee collector. Denoms it does not list are never swept.
+	DustSweepThreshold sdk.Coins
+	// A tx declaring more than GasOverDeclarationThreshold times the gas it
+	// used is charged GasOverDeclarationPenalty of its collected fee,
+	// discouraging inflated gas limits. The penalty is withheld from its
+	// unused gas refund, never charged on top of the fee; without
+	// RefundUnusedGas the kept fee already covers it. A zero threshold
+	// disables it.
+	GasOverDeclarationThreshold sdk.Dec
+	GasOverDeclarationPenalty   sdk.Dec
+}
+
+// FeeAllowancePool is an allowance of fees waived for a group of accounts.
+// The allowance is renewed every PeriodBlocks blocks, or never when zero.
+type FeeAllowancePool struct {
+	Name         string
+	Members      []string
+	Allowance    sdk.Coins
+	PeriodBlocks uint64
+}
+
+// Period returns the allowance period of the pool the given height is in.
+func (p FeeAllowancePool) Period(height int64) uint64 {
+	if p.PeriodBlocks == 0 || height <= 0 {
+		return 0
+	}
+
+	return uint64(height) / p.PeriodBlocks
+}
+
+// ChainZeroFeeExemption lists msg types exempt from RequireNonZeroFee on the
+// chain with the given chain-id only, letting networks sharing params, such
+// as a testnet and its mainnet, exempt different msgs.
+type ChainZeroFeeExemption struct {
+	ChainID  string
+	MsgTypes []string
+}
+
+// MinSupply is the least supply of a denom fee burns may leave.
+type MinSupply struct {
+	Denom  string
+	Amount sdk.Int
+}
+
+// RemovedDenoms returns the denoms listed in the AllowedDenoms of old but not
+// in those of p. A whitelist introduced where there was none removes no
+// listed denom, so nothing is returned for it.
+func (p FeeParams) RemovedDenoms(old FeeParams) []string {
+	var removed []string
+	for _, denom := range old.AllowedDenoms {
+		if !p.IsDenomAllowed(denom) {
+			removed = append(removed, denom)
+		}
+	}
+
+	return removed
+}
+
+// DenomExponent is the decimal exponent of a fee denom.
+type DenomExponent struct {
+	Denom    string
+	Exponent uint32
+}
+
+// SponsorSpendCap is the most an account pays in fees within a block.
+type SponsorSpendCap struct {
+	Address     string
+	PerBlockCap sdk.Coins
+}
+
+// DestinationPolicy sets where the fees paid in a denom are sent.
+type DestinationPolicy struct {
+	Denom       string
+	Destination string
+}
+
+// FeeSplit is the share of every fee paid to an address.
+type FeeSplit struct {
+	Address string
+	Weight  sdk.Dec
+}
+
+// ValidatorMinGasPrice is the local minimum gas price reported by a validator.
+type ValidatorMinGasPrice struct {
+	Validator    string
+	MinGasPrices sdk.DecCoins
+}
+
+func NewFeeparam(fee sdk.DecCoins, burnAmount sdk.Int, burnRate sdk.Dec) FeeParams {
+	return FeeParams{
+		Fee:              fee,
+		BurnAmount:       burnAmount,
+		BurnRate:         burnRate,
+		MinBurnAmount:    sdk.ZeroInt(),
+		FeeBumpRate:      sdk.ZeroDec(),
+		FeeMode:          FeeModeGas,
+		ValueGasPrice:    sdk.ZeroDec(),
+		EventVerbosity:   EventVerbosityMinimal,
+		OffPeakDiscount:  sdk.ZeroDec(),
+		OffPeakThreshold: sdk.ZeroDec(),
+		FallbackPenalty:  sdk.ZeroDec(),
+
+		LoadSheddingThreshold:       sdk.ZeroDec(),
+		LoadSheddingMultiplier:      sdk.ZeroDec(),
+		MaxFeeFractionOfBalance:     sdk.ZeroDec(),
+		GasOverDeclarationThreshold: sdk.ZeroDec(),
+		GasOverDeclarationPenalty:   sdk.ZeroDec(),
+	}
+}
+
+// DefaultParams returns the fee params set at genesis.
+func DefaultParams() FeeParams {
+	params := NewFeeparam(
+		sdk.NewDecCoins(sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(5))),
+		sdk.ZeroInt(),
+		sdk.ZeroDec(),
+	)
+	params.FeeBumpRate = sdk.NewDecWithPrec(1, 1)
+	params.BaseFeeChangeDenominator = 8
+	params.StatsRetentionBlocks = DefaultStatsRetentionBlocks
+
+	return params
+}
+
+// ParamKeyTable returns the key table for the fee module's param subspace.
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable(
+		paramtypes.NewParamSetPair(
+			ParamStoreKeyfee, FeeParams{}, ValidateFee,
+		),
+	)
+}
+
+func ValidateFee(i interface{}) error {
+	v, ok := i.(FeeParams)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.Fee.Empty() {
+		return fmt.Errorf("fee must be positive: %s", v.Fee.String())
+	}
+
+	if err := v.Fee.Validate(); err != nil {
+		return fmt.Errorf("invalid fee: %w", err)
+	}
+
+	if v.BurnAmount.IsNil() || !v.BurnAmount.GTE(sdk.NewInt(0)) {
+		return fmt.Errorf("burn amount must positive: %s ", v.BurnAmount.String())
+	}
+
+	if v.BurnRate.IsNil() || v.BurnRate.IsNegative() || v.BurnRate.GT(sdk.OneDec()) {
+		return fmt.Errorf("burn rate must be between 0 and 1: %s", v.BurnRate.String())
+	}
+
+	if v.MinBurnAmount.IsNil() || v.MinBurnAmount.IsNegative() {
+		return fmt.Errorf("min burn amount cannot be negative: %s", v.MinBurnAmount.String())
+	}
+
+	if v.FeeBumpRate.IsNil() || v.FeeBumpRate.IsNegative() {
+		return fmt.Errorf("fee bump rate cannot be negative: %s", v.FeeBumpRate.String())
+	}
+
+	allocated := v.BurnRate
+	for _, split := range v.FeeSplits {
+		if _, err := sdk.AccAddressFromBech32(split.Address); err != nil {
+			return fmt.Errorf("invalid fee split address %s: %w", split.Address, err)
+		}
+		if split.Weight.IsNil() || !split.Weight.IsPositive() {
+			return fmt.Errorf("fee split weight must be positive: %s", split.Weight)
+		}
+		allocated = allocated.Add(split.Weight)
+	}
+	if allocated.GT(sdk.OneDec()) {
+		return fmt.Errorf("burn rate and fee split weights allocate more than the whole fee: %s", allocated)
+	}
+
+	if v.BaseFeeEnabled {
+		if v.TargetBlockGas == 0 {
+			return fmt.Errorf("target block gas must be positive when the base fee is enabled")
+		}
+		if v.BaseFeeChangeDenominator == 0 {
+			return fmt.Errorf("base fee change denominator must be positive when the base fee is enabled")
+		}
+	}
+
+	if v.OffPeakDiscount.IsNil() || v.OffPeakDiscount.IsNegative() || v.OffPeakDiscount.GTE(sdk.OneDec()) {
+		return fmt.Errorf("off-peak discount must be at least 0 and below 1: %s", v.OffPeakDiscount.String())
+	}
+
+	if v.OffPeakThreshold.IsNil() || v.OffPeakThreshold.IsNegative() || v.OffPeakThreshold.GT(sdk.OneDec()) {
+		return fmt.Errorf("off-peak threshold must be between 0 and 1: %s", v.OffPeakThreshold.String())
+	}
+
+	if v.OffPeakDiscount.IsPositive() && v.TargetBlockGas == 0 {
+		return fmt.Errorf("target block gas must be positive when an off-peak discount is set")
+	}
+
+	if err := v.MinBaseFee.Validate(); err != nil {
+		return fmt.Errorf("invalid min base fee: %w", err)
+	}
+	if err := v.MaxBaseFee.Validate(); err != nil {
+		return fmt.Errorf("invalid max base fee: %w", err)
+	}
+	for _, max := range v.MaxBaseFee {
+		if min := v.MinBaseFee.AmountOf(max.Denom); min.GT(max.Amount) {
+			return fmt.Errorf("min base fee %s%s exceeds max base fee %s", min, max.Denom, max)
+		}
+	}
+
+	routed := make(map[string]bool, len(v.DestinationPolicies))
+	for _, policy := range v.DestinationPolicies {
+		if err := sdk.ValidateDenom(policy.Denom); err != nil {
+			return fmt.Errorf("invalid destination policy denom: %w", err)
+		}
+		if routed[policy.Denom] {
+			return fmt.Errorf("duplicate destination policy for denom: %s", policy.Denom)
+		}
+		switch policy.Destination {
+		case DestinationCollector, DestinationBurn, DestinationCommunityPool:
+		default:
+			return fmt.Errorf("invalid destination for denom %s: %s", policy.Denom, policy.Destination)
+		}
+		routed[policy.Denom] = true
+	}
+
+	if threshold := decOrZero(v.LoadSheddingThreshold); !threshold.IsZero() {
+		if threshold.IsNegative() {
+			return fmt.Errorf("load shedding threshold cannot be negative: %s", threshold)
+		}
+		if v.TargetBlockGas == 0 {
+			return fmt.Errorf("load shedding requires a target block gas")
+		}
+		if v.LoadSheddingMultiplier.IsNil() || v.LoadSheddingMultiplier.LT(sdk.OneDec()) {
+			return fmt.Errorf("load shedding multiplier must be at least 1: %s", v.LoadSheddingMultiplier)
+		}
+	}
+
+	if threshold := decOrZero(v.GasOverDeclarationThreshold); !threshold.IsZero() {
+		if threshold.LT(sdk.OneDec()) {
+			return fmt.Errorf("gas over declaration threshold must be at least 1: %s", threshold)
+		}
+		if penalty := decOrZero(v.GasOverDeclarationPenalty); penalty.IsNegative() || penalty.GT(sdk.OneDec()) {
+			return fmt.Errorf("gas over declaration penalty must be between 0 and 1: %s", penalty)
+		}
+	}
+
+	if fraction := decOrZero(v.MaxFeeFractionOfBalance); fraction.IsNegative() || fraction.GT(sdk.OneDec()) {
+		return fmt.Errorf("max fee fraction of balance must be between 0 and 1: %s", fraction)
+	}
+
+	if v.FallbackDenom != "" {
+		if err := sdk.ValidateDenom(v.FallbackDenom); err != nil {
+			return fmt.Errorf("invalid fallback denom: %w", err)
+		}
+		if v.FallbackPenalty.IsNil() || v.FallbackPenalty.IsNegative() {
+			return fmt.Errorf("fallback penalty cannot be negative: %s", v.FallbackPenalty)
+		}
+	}
+
+	switch v.FeeMode {
+	case "", FeeModeGas:
+	case FeeModeValue:
+		if v.ValueGasPrice.IsNil() || !v.ValueGasPrice.IsPositive() {
+			return fmt.Errorf("value gas price must be positive in %s fee mode: %s", FeeModeValue, v.ValueGasPrice)
+		}
+	default:
+		return fmt.Errorf("invalid fee mode: %s", v.FeeMode)
+	}
+
+	switch v.DustHandling {
+	case "", DustToCollector, DustToFirstRecipient, DustBurn:
+	default:
+		return fmt.Errorf("invalid dust handling: %s", v.DustHandling)
+	}
+
+	switch v.ConversionRounding {
+	case "", RoundingCeil, RoundingFloor:
+	default:
+		return fmt.Errorf("invalid conversion rounding: %s", v.ConversionRounding)
+	}
+
+	for _, r := range v.EventTypePrefix {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '.') {
+			return fmt.Errorf("invalid event type prefix: %s", v.EventTypePrefix)
+		}
+	}
+
+	switch v.EventVerbosity {
+	case "", EventVerbosityNone, EventVerbosityMinimal, EventVerbosityFull:
+	default:
+		return fmt.Errorf("invalid event verbosity: %s", v.EventVerbosity)
+	}
+
+	if err := v.MsgFee.Validate(); err != nil {
+		return fmt.Errorf("invalid msg fee: %w", err)
+	}
+	if err := v.ByteFee.Validate(); err != nil {
+		return fmt.Errorf("invalid byte fee: %w", err)
+	}
+	if err := v.SignatureFee.Validate(); err != nil {
+		return fmt.Errorf("invalid signature fee: %w", err)
+	}
+	if err := v.PerBlockMinFee.Validate(); err != nil {
+		return fmt.Errorf("invalid per block min fee: %w", err)
+	}
+	if err := v.DustSweepThreshold.Validate(); err != nil {
+		return fmt.Errorf("invalid dust sweep threshold: %w", err)
+	}
+	if err := v.MinFeePerDenom.Validate(); err != nil {
+		return fmt.Errorf("invalid min fee per denom: %w", err)
+	}
+	if err := v.RequireMemoAboveFee.Validate(); err != nil {
+		return fmt.Errorf("invalid memo fee threshold: %w", err)
+	}
+
+	minSupplies := make(map[string]bool, len(v.KeepMinSupply))
+	for _, ms := range v.KeepMinSupply {
+		if err := sdk.ValidateDenom(ms.Denom); err != nil {
+			return fmt.Errorf("invalid min supply denom: %w", err)
+		}
+		if minSupplies[ms.Denom] {
+			return fmt.Errorf("duplicate min supply for denom %s", ms.Denom)
+		}
+		if ms.Amount.IsNil() || ms.Amount.IsNegative() {
+			return fmt.Errorf("min supply of %s cannot be negative: %s", ms.Denom, ms.Amount)
+		}
+		minSupplies[ms.Denom] = true
+	}
+
+	if v.MinMempoolPriority < 0 {
+		return fmt.Errorf("min mempool priority cannot be negative: %d", v.MinMempoolPriority)
+	}
+
+	exponents := make(map[string]bool, len(v.DenomExponents))
+	for _, de := range v.DenomExponents {
+		if err := sdk.ValidateDenom(de.Denom); err != nil {
+			return fmt.Errorf("invalid denom exponent denom: %w", err)
+		}
+		if exponents[de.Denom] {
+			return fmt.Errorf("duplicate exponent for denom: %s", de.Denom)
+		}
+		if de.Exponent > MaxDenomExponent {
+			return fmt.Errorf("exponent for denom %s must be between 0 and %d: %d", de.Denom, MaxDenomExponent, de.Exponent)
+		}
+		exponents[de.Denom] = true
+	}
+
+	chainExemptions := make(map[string]bool, len(v.ChainZeroFeeExemptions))
+	for _, ce := range v.ChainZeroFeeExemptions {
+		if ce.ChainID == "" {
+			return fmt.Errorf("zero fee exemption chain-id cannot be empty")
+		}
+		if chainExemptions[ce.ChainID] {
+			return fmt.Errorf("duplicate zero fee exemptions for chain-id: %s", ce.ChainID)
+		}
+		chainExemptions[ce.ChainID] = true
+	}
+
+	exemptPayers := make(map[string]bool, len(v.FeeExemptPayers))
+	for _, payer := range v.FeeExemptPayers {
+		if _, err := sdk.AccAddressFromBech32(payer); err != nil {
+			return fmt.Errorf("invalid fee exempt payer %s: %w", payer, err)
+		}
+		if exemptPayers[payer] {
+			return fmt.Errorf("duplicate fee exempt payer: %s", payer)
+		}
+		exemptPayers[payer] = true
+	}
+
+	capped := make(map[string]bool, len(v.SponsorSpendCaps))
+	for _, sc := range v.SponsorSpendCaps {
+		if _, err := sdk.AccAddressFromBech32(sc.Address); err != nil {
+			return fmt.Errorf("invalid sponsor address %s: %w", sc.Address, err)
+		}
+		if capped[sc.Address] {
+			return fmt.Errorf("duplicate spend cap for sponsor: %s", sc.Address)
+		}
+		if err := sc.PerBlockCap.Validate(); err != nil {
+			return fmt.Errorf("invalid spend cap for sponsor %s: %w", sc.Address, err)
+		}
+		capped[sc.Address] = true
+	}
+
+	pools := make(map[string]bool, len(v.FeeAllowancePools))
+	members := make(map[string]bool)
+	for _, pool := range v.FeeAllowancePools {
+		if pool.Name == "" {
+			return fmt.Errorf("fee allowance pool name cannot be empty")
+		}
+		if pools[pool.Name] {
+			return fmt.Errorf("duplicate fee allowance pool: %s", pool.Name)
+		}
+		if err := pool.Allowance.Validate(); err != nil {
+			return fmt.Errorf("invalid allowance for fee allowance pool %s: %w", pool.Name, err)
+		}
+		for _, member := range pool.Members {
+			if _, err := sdk.AccAddressFromBech32(member); err != nil {
+				return fmt.Errorf("invalid member %s of fee allowance pool %s: %w", member, pool.Name, err)
+			}
+			if members[member] {
+				return fmt.Errorf("account %s is a member of more than one fee allowance pool", member)
+			}
+			members[member] = true
+		}
+		pools[pool.Name] = true
+	}
+
+	reported := make(map[string]bool, len(v.ValidatorMinGasPrices))
+	for _, vp := range v.ValidatorMinGasPrices {
+		if _, err := sdk.ValAddressFromBech32(vp.Validator); err != nil {
+			return fmt.Errorf("invalid validator address %s: %w", vp.Validator, err)
+		}
+		if reported[vp.Validator] {
+			return fmt.Errorf("duplicate min gas price report for validator: %s", vp.Validator)
+		}
+		if err := vp.MinGasPrices.Validate(); err != nil {
+			return fmt.Errorf("invalid min gas prices for validator %s: %w", vp.Validator, err)
+		}
+		reported[vp.Validator] = true
+	}
+
+	seen := make(map[string]bool, len(v.AllowedDenoms))
+	for _, denom := range v.AllowedDenoms {
+		if err := sdk.ValidateDenom(denom); err != nil {
+			return fmt.Errorf("invalid allowed denom: %w", err)
+		}
+		if seen[denom] {
+			return fmt.Errorf("duplicate allowed denom: %s", denom)
+		}
+		seen[denom] = true
+	}
+
+	return nil
+}
+
+// MinGasPrices returns the min gas prices of Fee in base units, scaling the
+// prices of denoms with a configured exponent.
+func (p FeeParams) MinGasPrices() sdk.DecCoins {
+	if len(p.DenomExponents) == 0 {
+		return p.Fee
+	}
+
+	prices := make(sdk.DecCoins, 0, len(p.Fee))
+	for _, price := range p.Fee {
+		amt := price.Amount
+		for _, de := range p.DenomExponents {
+			if de.Denom == price.Denom {
+				amt = amt.MulInt(sdk.NewIntWithDecimal(1, int(de.Exponent)))
+				break
+			}
+		}
+		prices = append(prices, sdk.NewDecCoinFromDec(price.Denom, amt))
+	}
+
+	return prices
+}
+
+// IsZeroFeeExempt reports whether a tx with the given msg type URLs may pay
+// no fee on the chain with the given chain-id.
+func (p FeeParams) IsZeroFeeExempt(chainID string, msgTypes []string) bool {
+	if len(msgTypes) == 0 {
+		return false
+	}
+
+	exempt := make(map[string]bool, len(p.ZeroFeeExemptMsgs))
+	for _, t := range p.ZeroFeeExemptMsgs {
+		exempt[t] = true
+	}
+	for _, ce := range p.ChainZeroFeeExemptions {
+		if ce.ChainID != chainID {
+			continue
+		}
+		for _, t := range ce.MsgTypes {
+			exempt[t] = true
+		}
+	}
+
+	for _, msgType := range msgTypes {
+		if !exempt[msgType] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsFeeExemptPayer reports whether payer is one of the FeeExemptPayers.
+func (p FeeParams) IsFeeExemptPayer(payer sdk.AccAddress) bool {
+	for _, exempt := range p.FeeExemptPayers {
+		if exempt == payer.String() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsDenomAllowed reports whether fees may be paid in the given denom. It does
+// not account for the grace period of removed denoms, see
+// Keeper.IsDenomAccepted.
+func (p FeeParams) IsDenomAllowed(denom string) bool {
+	if len(p.AllowedDenoms) == 0 {
+		return true
+	}
+
+	for _, d := range p.AllowedDenoms {
+		if d == denom {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Destination returns where fees paid in denom are sent.
+func (p FeeParams) Destination(denom string) string {
+	for _, policy := range p.DestinationPolicies {
+		if policy.Denom == denom {
+			return policy.Destination
+		}
+	}
+
+	return DestinationCollector
+}
+
+// IsOffPeak reports whether a block that used gasUsed gas leaves the next
+// block off-peak.
+func (p FeeParams) IsOffPeak(gasUsed uint64) bool {
+	if p.TargetBlockGas == 0 || !decOrZero(p.OffPeakDiscount).IsPositive() {
+		return false
+	}
+
+	threshold := decOrZero(p.OffPeakThreshold).MulInt64(int64(p.TargetBlockGas))
+	return sdk.NewDec(int64(gasUsed)).LT(threshold)
+}
+
+// MinSupply returns the least supply fee burns may leave of denom.
+func (p FeeParams) MinSupply(denom string) (sdk.Int, bool) {
+	for _, ms := range p.KeepMinSupply {
+		if ms.Denom == denom {
+			return ms.Amount, true
+		}
+	}
+
+	return sdk.Int{}, false
+}
+
+// BurnableAt returns the coins of burn that may be burned out of supply:
+// denoms whose supply the burn would take below their KeepMinSupply are left
+// out.
+func (p FeeParams) BurnableAt(supply, burn sdk.Coins) sdk.Coins {
+	burnable := sdk.NewCoins()
+	for _, coin := range burn {
+		if min, ok := p.MinSupply(coin.Denom); ok && supply.AmountOf(coin.Denom).Sub(coin.Amount).LT(min) {
+			continue
+		}
+		burnable = burnable.Add(coin)
+	}
+
+	return burnable
+}
+
+// RequiresMemo reports whether a tx paying fee must carry a memo.
+func (p FeeParams) RequiresMemo(fee sdk.Coins) bool {
+	return fee.IsAnyGT(p.RequireMemoAboveFee)
+}
+
+// IsLoadShedding reports whether a block that used gasUsed gas makes the next
+// block shed load.
+func (p FeeParams) IsLoadShedding(gasUsed uint64) bool {
+	threshold := decOrZero(p.LoadSheddingThreshold)
+	if p.TargetBlockGas == 0 || !threshold.IsPositive() {
+		return false
+	}
+
+	return sdk.NewDec(int64(gasUsed)).GT(threshold.MulInt64(int64(p.TargetBlockGas)))
+}
+
+// AllowancePool returns the fee allowance pool addr is a member of.
+func (p FeeParams) AllowancePool(addr sdk.AccAddress) (FeeAllowancePool, bool) {
+	for _, pool := range p.FeeAllowancePools {
+		for _, member := range pool.Members {
+			if member == addr.String() {
+				return pool, true
+			}
+		}
+	}
+
+	return FeeAllowancePool{}, false
+}
+
+// SpendCap returns the per-block spend cap configured for addr.
+func (p FeeParams) SpendCap(addr sdk.AccAddress) (sdk.Coins, bool) {
+	for _, sc := range p.SponsorSpendCaps {
+		if sc.Address == addr.String() {
+			return sc.PerBlockCap, true
+		}
+	}
+
+	return nil, false
+}
+
+// EmitMinimalEvents reports whether the fee_deducted event is emitted.
+func (p FeeParams) EmitMinimalEvents() bool {
+	return p.EventVerbosity != EventVerbosityNone
+}
+
+// EmitFullEvents reports whether the detailed fee events are emitted.
+func (p FeeParams) EmitFullEvents() bool {
+	return p.EventVerbosity == EventVerbosityFull
+}
+
+// decOrZero returns d, or zero if d was never set. Params stored before a
+// field was introduced decode it as nil.
+func decOrZero(d sdk.Dec) sdk.Dec {
+	if d.IsNil() {
+		return sdk.ZeroDec()
+	}
+
+	return d
+}
+
+// RoundConversion rounds an amount converted through the oracle to whole
+// coins in the direction set by ConversionRounding, up by default.
+func (p FeeParams) RoundConversion(amount sdk.Dec) sdk.Int {
+	if p.ConversionRounding == RoundingFloor {
+		return amount.TruncateInt()
+	}
+
+	return amount.Ceil().TruncateInt()
+}
+
+// ExceedsBalanceFraction reports whether fee is more than
+// MaxFeeFractionOfBalance of the spendable balance in any of its denoms.
+func (p FeeParams) ExceedsBalanceFraction(fee, spendable sdk.Coins) bool {
+	fraction := decOrZero(p.MaxFeeFractionOfBalance)
+	if !fraction.IsPositive() {
+		return false
+	}
+
+	for _, coin := range fee {
+		if coin.Amount.ToDec().GT(spendable.AmountOf(coin.Denom).ToDec().Mul(fraction)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EventType returns the type of a fee event, namespaced by EventTypePrefix.
+func (p FeeParams) EventType(eventType string) string {
+	if p.EventTypePrefix == "" {
+		return eventType
+	}
+
+	return p.EventTypePrefix + "." + eventType
+}
+
+// IsGasOverDeclared reports whether a tx declaring gasWanted declared more
+// than GasOverDeclarationThreshold times the gasUsed it used.
+func (p FeeParams) IsGasOverDeclared(gasWanted, gasUsed uint64) bool {
+	if !p.PenalizesGasOverDeclaration() {
+		return false
+	}
+
+	return sdk.NewDecFromInt(sdk.NewIntFromUint64(gasWanted)).GT(p.GasOverDeclarationThreshold.MulInt(sdk.NewIntFromUint64(gasUsed)))
+}
+
+// PenalizesGasOverDeclaration reports whether txs over declaring their gas
+// are penalized, see GasOverDeclarationThreshold.
+func (p FeeParams) PenalizesGasOverDeclaration() bool {
+	return decOrZero(p.GasOverDeclarationThreshold).IsPositive()
+}
+
+// GasOverDeclarationPenaltyOf returns the GasOverDeclarationPenalty share of
+// a collected fee, rounded down.
+func (p FeeParams) GasOverDeclarationPenaltyOf(fee sdk.Coins) sdk.Coins {
+	rate := decOrZero(p.GasOverDeclarationPenalty)
+	penalty := sdk.NewCoins()
+	for _, coin := range fee {
+		if amt := coin.Amount.ToDec().Mul(rate).TruncateInt(); amt.IsPositive() {
+			penalty = penalty.Add(sdk.NewCoin(coin.Denom, amt))
+		}
+	}
+
+	return penalty
+}
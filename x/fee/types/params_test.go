@@ -0,0 +1,41 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func newTestAddr() sdk.AccAddress {
+	return sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+}
+
+func TestValidateFeeAllocation(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		weights []int64
+		valid   bool
+	}{
+		{"120% allocated", []int64{60, 40}, false},
+		{"100% allocated", []int64{50, 30}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			params := types.DefaultParams()
+			params.BurnRate = sdk.NewDecWithPrec(2, 1)
+			for _, weight := range tc.weights {
+				params.FeeSplits = append(params.FeeSplits, types.FeeSplit{Address: newTestAddr().String(), Weight: sdk.NewDecWithPrec(weight, 2)})
+			}
+
+			err := types.ValidateFee(params)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
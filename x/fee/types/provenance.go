@@ -0,0 +1,19 @@
+package types
+
+// sources of a fee params change
+const (
+	// ParamSourceGovernance is a param change proposal passed by governance.
+	ParamSourceGovernance = "governance"
+	// ParamSourceAuthority is an UpdateParams call by the keeper's authority.
+	ParamSourceAuthority = "authority"
+)
+
+// ParamsProvenance records the change that set the current fee params.
+// Reference identifies the change within its source, the proposal ID for
+// governance changes.
+type ParamsProvenance struct {
+	Height    int64  `json:"height" yaml:"height"`
+	Authority string `json:"authority" yaml:"authority"`
+	Source    string `json:"source" yaml:"source"`
+	Reference string `json:"reference,omitempty" yaml:"reference,omitempty"`
+}
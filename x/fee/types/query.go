@@ -1 +1,434 @@
 package types
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the fee querier
+const (
+	QueryGasPriceHistory      = "gas-price-history"
+	QuerySuggestFeeBump       = "suggest-fee-bump"
+	QueryNetworkMinGasPrice   = "network-min-gas-price"
+	QueryEstimateFees         = "estimate-fees"
+	QueryEffectiveConfig      = "effective-config"
+	QueryParams               = "params"
+	QueryProjectSupply        = "project-supply"
+	QueryFeeAllocation        = "fee-allocation"
+	QueryPendingBurn          = "pending-burn"
+	QueryValidateParams       = "validate-params"
+	QuerySimulateBurn         = "simulate-burn"
+	QueryFeatures             = "features"
+	QueryAuthority            = "authority"
+	QueryRequiredFeeBreakdown = "required-fee-breakdown"
+	QueryConversionRates      = "conversion-rates"
+	QueryTxCount              = "tx-count"
+	QueryMinGasPriceInDenom   = "min-gas-price-in-denom"
+	QueryBurnStatus           = "burn-status"
+	QueryEffectiveBurn        = "effective-burn"
+	QueryParamsProvenance     = "params-provenance"
+	QueryMaxGasForFee         = "max-gas-for-fee"
+	QueryDeflationRate        = "deflation-rate"
+	QueryComputePriority      = "compute-priority"
+	QuerySweepableDust        = "sweepable-dust"
+	QueryFeeStatsByDenom      = "fee-stats-by-denom"
+)
+
+// MaxQueryHeightRange is the largest number of blocks a query over a height
+// range may cover.
+const MaxQueryHeightRange int64 = 10000
+
+// QueryGasPriceHistoryRequest is the request type for the gas price history
+// query. Both heights are inclusive.
+type QueryGasPriceHistoryRequest struct {
+	FromHeight int64   `json:"from_height" yaml:"from_height"`
+	ToHeight   int64   `json:"to_height" yaml:"to_height"`
+	Denom      string  `json:"denom" yaml:"denom"`
+	Precision  *uint32 `json:"precision,omitempty" yaml:"precision,omitempty"`
+}
+
+// QueryGasPriceHistoryResponse is the response type for the gas price history
+// query.
+type QueryGasPriceHistoryResponse struct {
+	Prices []GasPricePoint `json:"prices" yaml:"prices"`
+}
+
+// GasPricePoint is the average effective gas price paid in a block.
+type GasPricePoint struct {
+	Height   int64   `json:"height" yaml:"height"`
+	GasPrice sdk.Dec `json:"gas_price" yaml:"gas_price"`
+}
+
+// QueryTxCountRequest is the request type for the fee-paying tx count query.
+// Both heights are inclusive.
+type QueryTxCountRequest struct {
+	FromHeight int64 `json:"from_height" yaml:"from_height"`
+	ToHeight   int64 `json:"to_height" yaml:"to_height"`
+}
+
+// QueryTxCountResponse is the response type for the fee-paying tx count query.
+type QueryTxCountResponse struct {
+	Count uint64 `json:"count" yaml:"count"`
+}
+
+// QueryFeeStatsByDenomRequest is the request type for the per-denom fee stats
+// query. Both heights are inclusive.
+type QueryFeeStatsByDenomRequest struct {
+	FromHeight int64 `json:"from_height" yaml:"from_height"`
+	ToHeight   int64 `json:"to_height" yaml:"to_height"`
+}
+
+// QueryFeeStatsByDenomResponse is the response type for the per-denom fee
+// stats query, sorted by denom.
+type QueryFeeStatsByDenomResponse struct {
+	Denoms []DenomFeeTotals `json:"denoms" yaml:"denoms"`
+}
+
+// DenomFeeTotals are the fees collected and burned in a denom over a range of
+// blocks.
+type DenomFeeTotals struct {
+	Denom     string  `json:"denom" yaml:"denom"`
+	Collected sdk.Int `json:"collected" yaml:"collected"`
+	Burned    sdk.Int `json:"burned" yaml:"burned"`
+}
+
+// QuerySuggestFeeBumpRequest is the request type for the fee bump suggestion
+// query.
+type QuerySuggestFeeBumpRequest struct {
+	CurrentFee sdk.Coins `json:"current_fee" yaml:"current_fee"`
+	Gas        uint64    `json:"gas" yaml:"gas"`
+}
+
+// QuerySuggestFeeBumpResponse is the response type for the fee bump suggestion
+// query.
+type QuerySuggestFeeBumpResponse struct {
+	RequiredFee  sdk.Coins `json:"required_fee" yaml:"required_fee"`
+	SuggestedFee sdk.Coins `json:"suggested_fee" yaml:"suggested_fee"`
+}
+
+// QueryNetworkMinGasPriceRequest is the request type for the network min gas
+// price query.
+type QueryNetworkMinGasPriceRequest struct {
+	Precision *uint32 `json:"precision,omitempty" yaml:"precision,omitempty"`
+}
+
+// QueryNetworkMinGasPriceResponse is the response type for the network min gas
+// price query.
+type QueryNetworkMinGasPriceResponse struct {
+	MinGasPrices sdk.DecCoins `json:"min_gas_prices" yaml:"min_gas_prices"`
+}
+
+// QueryEstimateFeesRequest is the request type for the fee estimate query.
+type QueryEstimateFeesRequest struct {
+	Gas uint64 `json:"gas" yaml:"gas"`
+}
+
+// QueryEstimateFeesResponse is the response type for the fee estimate query.
+// GrossFee is what the user pays, NetFee what the chain keeps after Burned.
+type QueryEstimateFeesResponse struct {
+	GrossFee sdk.Coins `json:"gross_fee" yaml:"gross_fee"`
+	Burned   sdk.Coins `json:"burned" yaml:"burned"`
+	NetFee   sdk.Coins `json:"net_fee" yaml:"net_fee"`
+}
+
+// QueryMaxGasForFeeRequest is the request type for the max gas for fee query.
+// Only the part of Fee paid in Denom is priced.
+type QueryMaxGasForFeeRequest struct {
+	Fee   sdk.Coins `json:"fee" yaml:"fee"`
+	Denom string    `json:"denom" yaml:"denom"`
+}
+
+// QueryMaxGasForFeeResponse is the response type for the max gas for fee
+// query.
+type QueryMaxGasForFeeResponse struct {
+	Gas uint64 `json:"gas" yaml:"gas"`
+}
+
+// QueryComputePriorityRequest is the request type for the compute priority
+// query.
+type QueryComputePriorityRequest struct {
+	Fee sdk.Coins `json:"fee" yaml:"fee"`
+	Gas uint64    `json:"gas" yaml:"gas"`
+}
+
+// QueryComputePriorityResponse is the response type for the compute priority
+// query.
+type QueryComputePriorityResponse struct {
+	Priority int64 `json:"priority" yaml:"priority"`
+}
+
+// QueryRequiredFeeBreakdownRequest is the request type for the required fee
+// breakdown query, describing the tx to price.
+type QueryRequiredFeeBreakdownRequest struct {
+	Gas   uint64 `json:"gas" yaml:"gas"`
+	Msgs  uint64 `json:"msgs" yaml:"msgs"`
+	Bytes uint64 `json:"bytes" yaml:"bytes"`
+	Sigs  uint64 `json:"sigs" yaml:"sigs"`
+}
+
+// QueryRequiredFeeBreakdownResponse is the response type for the required fee
+// breakdown query.
+type QueryRequiredFeeBreakdownResponse struct {
+	Breakdown RequiredFeeBreakdown `json:"breakdown" yaml:"breakdown"`
+}
+
+// QueryEffectiveConfigRequest is the request type for the effective config
+// query.
+type QueryEffectiveConfigRequest struct {
+	Precision *uint32 `json:"precision,omitempty" yaml:"precision,omitempty"`
+}
+
+// QueryEffectiveConfigResponse is the response type for the effective config
+// query. MinGasPrices is the resolved value enforced by the ante handler.
+type QueryEffectiveConfigResponse struct {
+	MinGasPrices      sdk.DecCoins `json:"min_gas_prices" yaml:"min_gas_prices"`
+	ParamMinGasPrices sdk.DecCoins `json:"param_min_gas_prices" yaml:"param_min_gas_prices"`
+	LocalMinGasPrices sdk.DecCoins `json:"local_min_gas_prices" yaml:"local_min_gas_prices"`
+	BaseFee           sdk.DecCoins `json:"base_fee" yaml:"base_fee"`
+	BurnRate          sdk.Dec      `json:"burn_rate" yaml:"burn_rate"`
+	FeeMode           string       `json:"fee_mode" yaml:"fee_mode"`
+	OffPeak           bool         `json:"off_peak" yaml:"off_peak"`
+}
+
+// QueryParamsResponse is the response type for the params query.
+// BurnRatePercent renders the burn rate for display, e.g. "15.5%".
+type QueryParamsResponse struct {
+	Params          FeeParams `json:"params" yaml:"params"`
+	BurnRatePercent string    `json:"burn_rate_percent" yaml:"burn_rate_percent"`
+}
+
+// FormatPercent renders a fraction as a percentage without trailing zeros,
+// e.g. 0.155 as "15.5%".
+func FormatPercent(d sdk.Dec) string {
+	if d.IsNil() {
+		d = sdk.ZeroDec()
+	}
+
+	s := d.MulInt64(100).String()
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+
+	return s + "%"
+}
+
+// QueryBurnStatusResponse is the response type for the burn status query.
+// Enabled reports whether any fee is burned, through the burn rate or a denom
+// routed to burn, Destination is the account burned fees pass through and
+// PendingBurn what the end of the current block burns from the collector.
+type QueryBurnStatusResponse struct {
+	Enabled     bool      `json:"enabled" yaml:"enabled"`
+	Rate        sdk.Dec   `json:"rate" yaml:"rate"`
+	Destination string    `json:"destination" yaml:"destination"`
+	PendingBurn sdk.Coins `json:"pending_burn" yaml:"pending_burn"`
+}
+
+// QueryEffectiveBurnRequest is the request type for the effective burn query.
+type QueryEffectiveBurnRequest struct {
+	Fee sdk.Coins `json:"fee" yaml:"fee"`
+}
+
+// QueryEffectiveBurnResponse is the response type for the effective burn
+// query, with the burned part of the fee in total and per denom.
+type QueryEffectiveBurnResponse struct {
+	Burned sdk.Coins   `json:"burned" yaml:"burned"`
+	Denoms []DenomBurn `json:"denoms" yaml:"denoms"`
+}
+
+// DenomBurn is the part of the fee paid in Denom that is burned.
+type DenomBurn struct {
+	Denom  string  `json:"denom" yaml:"denom"`
+	Fee    sdk.Int `json:"fee" yaml:"fee"`
+	Burned sdk.Int `json:"burned" yaml:"burned"`
+}
+
+// QueryValidateParamsRequest is the request type for the validate params
+// query.
+type QueryValidateParamsRequest struct {
+	Params FeeParams `json:"params" yaml:"params"`
+}
+
+// QueryValidateParamsResponse is the response type for the validate params
+// query. Error holds the validation failure when Valid is false.
+type QueryValidateParamsResponse struct {
+	Valid bool   `json:"valid" yaml:"valid"`
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// QueryFeaturesResponse is the response type for the features query. It
+// reports which fee features the current params enable.
+type QueryFeaturesResponse struct {
+	FeeMode              string `json:"fee_mode" yaml:"fee_mode"`
+	Burn                 bool   `json:"burn" yaml:"burn"`
+	BurnConfirmations    bool   `json:"burn_confirmations" yaml:"burn_confirmations"`
+	FeeSplits            bool   `json:"fee_splits" yaml:"fee_splits"`
+	DustHandling         string `json:"dust_handling" yaml:"dust_handling"`
+	DynamicBaseFee       bool   `json:"dynamic_base_fee" yaml:"dynamic_base_fee"`
+	OffPeakDiscount      bool   `json:"off_peak_discount" yaml:"off_peak_discount"`
+	DenomWhitelist       bool   `json:"denom_whitelist" yaml:"denom_whitelist"`
+	DestinationRouting   bool   `json:"destination_routing" yaml:"destination_routing"`
+	FallbackDenom        bool   `json:"fallback_denom" yaml:"fallback_denom"`
+	Surcharges           bool   `json:"surcharges" yaml:"surcharges"`
+	StateRent            bool   `json:"state_rent" yaml:"state_rent"`
+	MinGasPerByte        bool   `json:"min_gas_per_byte" yaml:"min_gas_per_byte"`
+	SponsorSpendCaps     bool   `json:"sponsor_spend_caps" yaml:"sponsor_spend_caps"`
+	FeeAllocationRecords bool   `json:"fee_allocation_records" yaml:"fee_allocation_records"`
+	ParamChangeRateLimit bool   `json:"param_change_rate_limit" yaml:"param_change_rate_limit"`
+	Oracle               bool   `json:"oracle" yaml:"oracle"`
+	RefundUnusedGas      bool   `json:"refund_unused_gas" yaml:"refund_unused_gas"`
+}
+
+// QueryConversionRatesResponse is the response type for the conversion rates
+// query, listing the oracle rates of the fee denoms at Height.
+type QueryConversionRatesResponse struct {
+	Height int64       `json:"height" yaml:"height"`
+	Rates  []DenomRate `json:"rates" yaml:"rates"`
+}
+
+// DenomRate is the value of one unit of Denom in the oracle's base denom.
+type DenomRate struct {
+	Denom string  `json:"denom" yaml:"denom"`
+	Rate  sdk.Dec `json:"rate" yaml:"rate"`
+}
+
+// QueryMinGasPriceInDenomRequest is the request type for the min gas price in
+// a reference denom query.
+type QueryMinGasPriceInDenomRequest struct {
+	ReferenceDenom string `json:"reference_denom" yaml:"reference_denom"`
+}
+
+// QueryMinGasPriceInDenomResponse is the response type for the min gas price
+// in a reference denom query.
+type QueryMinGasPriceInDenomResponse struct {
+	ReferenceDenom string           `json:"reference_denom" yaml:"reference_denom"`
+	Prices         []ReferencePrice `json:"prices" yaml:"prices"`
+}
+
+// ReferencePrice is a min gas price together with its value in the reference
+// denom.
+type ReferencePrice struct {
+	GasPrice          sdk.DecCoin `json:"gas_price" yaml:"gas_price"`
+	ReferenceGasPrice sdk.Dec     `json:"reference_gas_price" yaml:"reference_gas_price"`
+}
+
+// QueryParamsProvenanceResponse is the response type for the params
+// provenance query.
+type QueryParamsProvenanceResponse struct {
+	Provenance ParamsProvenance `json:"provenance" yaml:"provenance"`
+}
+
+// QueryAuthorityResponse is the response type for the authority query.
+type QueryAuthorityResponse struct {
+	Authority string `json:"authority" yaml:"authority"`
+}
+
+// TruncateDec truncates d to the given number of decimal places for display.
+// A nil precision leaves d at full precision.
+func TruncateDec(d sdk.Dec, precision *uint32) sdk.Dec {
+	if precision == nil || d.IsNil() || *precision >= sdk.Precision {
+		return d
+	}
+
+	scale := sdk.NewIntWithDecimal(1, int(*precision))
+	return d.MulInt(scale).TruncateInt().ToDec().QuoInt(scale)
+}
+
+// TruncateDecCoins truncates every amount of coins to the given number of
+// decimal places for display, see TruncateDec.
+func TruncateDecCoins(coins sdk.DecCoins, precision *uint32) sdk.DecCoins {
+	if precision == nil {
+		return coins
+	}
+
+	truncated := make(sdk.DecCoins, 0, len(coins))
+	for _, coin := range coins {
+		truncated = append(truncated, sdk.NewDecCoinFromDec(coin.Denom, TruncateDec(coin.Amount, precision)))
+	}
+
+	return truncated
+}
+
+// QueryProjectSupplyRequest is the request type for the projected supply
+// query. AvgFeePerBlock is the expected fee throughput in Denom.
+type QueryProjectSupplyRequest struct {
+	Denom          string  `json:"denom" yaml:"denom"`
+	BlocksAhead    uint64  `json:"blocks_ahead" yaml:"blocks_ahead"`
+	AvgFeePerBlock sdk.Int `json:"avg_fee_per_block" yaml:"avg_fee_per_block"`
+}
+
+// QueryProjectSupplyResponse is the response type for the projected supply
+// query.
+type QueryProjectSupplyResponse struct {
+	CurrentSupply   sdk.Int `json:"current_supply" yaml:"current_supply"`
+	BurnPerBlock    sdk.Int `json:"burn_per_block" yaml:"burn_per_block"`
+	ProjectedSupply sdk.Int `json:"projected_supply" yaml:"projected_supply"`
+}
+
+// DefaultBlocksPerDay is the number of blocks per day assumed by the burn
+// simulation when none is given, at a 6 second block time.
+const DefaultBlocksPerDay = 14400
+
+// QuerySimulateBurnRequest is the request type for the burn simulation query.
+// BurnRate replaces the current burn rate, and BlocksPerDay defaults to
+// DefaultBlocksPerDay.
+type QuerySimulateBurnRequest struct {
+	AvgFeePerBlock sdk.Coins `json:"avg_fee_per_block" yaml:"avg_fee_per_block"`
+	BurnRate       sdk.Dec   `json:"burn_rate" yaml:"burn_rate"`
+	BlocksPerDay   uint64    `json:"blocks_per_day" yaml:"blocks_per_day"`
+}
+
+// QuerySimulateBurnResponse is the response type for the burn simulation
+// query.
+type QuerySimulateBurnResponse struct {
+	BurnPerBlock sdk.Coins `json:"burn_per_block" yaml:"burn_per_block"`
+	BurnPerDay   sdk.Coins `json:"burn_per_day" yaml:"burn_per_day"`
+}
+
+// QueryDeflationRateRequest is the request type for the deflation rate query.
+// The burns of the last Blocks blocks, capped at and defaulting to
+// MaxQueryHeightRange, are annualized at BlocksPerDay, which defaults to
+// DefaultBlocksPerDay.
+type QueryDeflationRateRequest struct {
+	Denom        string `json:"denom" yaml:"denom"`
+	Blocks       uint64 `json:"blocks" yaml:"blocks"`
+	BlocksPerDay uint64 `json:"blocks_per_day" yaml:"blocks_per_day"`
+}
+
+// QueryDeflationRateResponse is the response type for the deflation rate
+// query. Burned is what the Blocks blocks of the window burned, and
+// AnnualizedPercent the share of Supply burned in a year at that throughput.
+type QueryDeflationRateResponse struct {
+	Supply            sdk.Int `json:"supply" yaml:"supply"`
+	Burned            sdk.Int `json:"burned" yaml:"burned"`
+	Blocks            uint64  `json:"blocks" yaml:"blocks"`
+	AnnualizedPercent sdk.Dec `json:"annualized_percent" yaml:"annualized_percent"`
+}
+
+// QueryFeeAllocationRequest is the request type for the fee allocation query.
+// TxHash is the hex encoded tx hash.
+type QueryFeeAllocationRequest struct {
+	TxHash string `json:"tx_hash" yaml:"tx_hash"`
+}
+
+// QueryFeeAllocationResponse is the response type for the fee allocation
+// query.
+type QueryFeeAllocationResponse struct {
+	Allocation FeeAllocation `json:"allocation" yaml:"allocation"`
+}
+
+// QuerySweepableDustResponse is the response type for the sweepable dust
+// query, listing what SweepDust would move to the fee collector.
+type QuerySweepableDustResponse struct {
+	Dust sdk.Coins `json:"dust" yaml:"dust"`
+}
+
+// QueryPendingBurnResponse is the response type for the pending burn query.
+// Accumulated is the fee collector balance the burn rate applies to,
+// NextBurn the part of it burned at the end of the current block, and
+// Unconfirmed the burns still waiting for their confirmations.
+type QueryPendingBurnResponse struct {
+	Accumulated sdk.Coins `json:"accumulated" yaml:"accumulated"`
+	NextBurn    sdk.Coins `json:"next_burn" yaml:"next_burn"`
+	Unconfirmed sdk.Coins `json:"unconfirmed" yaml:"unconfirmed"`
+}
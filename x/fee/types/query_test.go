@@ -0,0 +1,22 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/marbar3778/fee/x/fee/types"
+)
+
+func TestFormatPercent(t *testing.T) {
+	for rate, expected := range map[string]string{
+		"0.155": "15.5%",
+		"0.15":  "15%",
+		"1":     "100%",
+		"0":     "0%",
+	} {
+		require.Equal(t, expected, types.FormatPercent(sdk.MustNewDecFromStr(rate)), rate)
+	}
+	require.Equal(t, "0%", types.FormatPercent(sdk.Dec{}))
+}
@@ -0,0 +1,14 @@
+package types
+
+// FeeReceiptMsgType is the msg type of the tx result data entry carrying the
+// fee receipt.
+const FeeReceiptMsgType = "/marbar3778.fee.fee.FeeReceipt"
+
+// NewFeeReceipt returns the receipt for a fee allocation.
+func NewFeeReceipt(allocation FeeAllocation) FeeReceipt {
+	return FeeReceipt{
+		Collected:  allocation.Collected,
+		Burned:     allocation.Burned,
+		Recipients: allocation.Recipients,
+	}
+}
@@ -0,0 +1,19 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GasCharge is the part of a tx fee that reached the fee collector, together
+// with the gas it paid for, kept until the gas the tx used is known.
+type GasCharge struct {
+	Payer     string    `json:"payer" yaml:"payer"`
+	Collected sdk.Coins `json:"collected" yaml:"collected"`
+	GasWanted uint64    `json:"gas_wanted" yaml:"gas_wanted"`
+}
+
+// GasRefund is an unused gas refund owed to Payer at the end of the block.
+type GasRefund struct {
+	Payer  string    `json:"payer" yaml:"payer"`
+	Amount sdk.Coins `json:"amount" yaml:"amount"`
+}
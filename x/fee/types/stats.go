@@ -0,0 +1,77 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BlockFeeStats aggregates the fees paid by the txs of a single block.
+type BlockFeeStats struct {
+	Height    int64           `json:"height" yaml:"height"`
+	Collected sdk.Coins       `json:"collected" yaml:"collected"`
+	GasWanted uint64          `json:"gas_wanted" yaml:"gas_wanted"`
+	Denoms    []DenomFeeStats `json:"denoms" yaml:"denoms"`
+	// Burned is every fee burn of the block: routed denoms, split dust and
+	// the end of block collector burn.
+	Burned sdk.Coins `json:"burned" yaml:"burned"`
+	// TxCount is the number of txs that paid a non-zero fee.
+	TxCount uint64 `json:"tx_count" yaml:"tx_count"`
+}
+
+// DenomFeeStats aggregates the fees paid in a single denom within a block.
+// GasWanted is the gas declared by the txs that paid in this denom.
+type DenomFeeStats struct {
+	Denom     string  `json:"denom" yaml:"denom"`
+	Collected sdk.Int `json:"collected" yaml:"collected"`
+	GasWanted uint64  `json:"gas_wanted" yaml:"gas_wanted"`
+}
+
+// AddFee records a tx paying fees for the given gas limit. Only txs paying a
+// non-zero fee count towards TxCount.
+func (s *BlockFeeStats) AddFee(fees sdk.Coins, gas uint64) {
+	s.Collected = s.Collected.Add(fees...)
+	s.GasWanted += gas
+	if !fees.IsZero() {
+		s.TxCount++
+	}
+
+	for _, fee := range fees {
+		s.addDenomFee(fee, gas)
+	}
+}
+
+// AddBurn records fees burned in the block.
+func (s *BlockFeeStats) AddBurn(burned sdk.Coins) {
+	s.Burned = s.Burned.Add(burned...)
+}
+
+func (s *BlockFeeStats) addDenomFee(fee sdk.Coin, gas uint64) {
+	for i, d := range s.Denoms {
+		if d.Denom == fee.Denom {
+			s.Denoms[i].Collected = d.Collected.Add(fee.Amount)
+			s.Denoms[i].GasWanted += gas
+			return
+		}
+	}
+
+	s.Denoms = append(s.Denoms, DenomFeeStats{Denom: fee.Denom, Collected: fee.Amount, GasWanted: gas})
+}
+
+// Denom returns the stats for the given denom, if any fee was paid in it.
+func (s BlockFeeStats) Denom(denom string) (DenomFeeStats, bool) {
+	for _, d := range s.Denoms {
+		if d.Denom == denom {
+			return d, true
+		}
+	}
+
+	return DenomFeeStats{}, false
+}
+
+// AverageGasPrice returns the average effective gas price paid in the denom.
+func (d DenomFeeStats) AverageGasPrice() sdk.Dec {
+	if d.GasWanted == 0 {
+		return sdk.ZeroDec()
+	}
+
+	return d.Collected.ToDec().QuoInt64(int64(d.GasWanted))
+}